@@ -2,6 +2,9 @@ package ds
 
 import (
 	"fmt"
+	neturl "net/url"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -46,6 +49,140 @@ func OptRaw(key, value string) option {
 	return sseOption{key: key, value: value, raw: true}
 }
 
+// ---------------------------------------------------------------------------
+// Typed option constructors
+//
+// These wrap Opt/OptRaw with real Go types so the option catalogue documented
+// at https://data-star.dev/reference/actions#options gets compile-time
+// checking instead of free-form key/value strings.
+// ---------------------------------------------------------------------------
+
+// Cancellation selects the requestCancellation option value.
+type Cancellation string
+
+const (
+	CancellationEnabled  Cancellation = "enabled"
+	CancellationDisabled Cancellation = "disabled"
+)
+
+// RequestCancellation sets whether an in-flight request is aborted when a new
+// one is triggered from the same element.
+//
+//	ds.Get("/api/updates", ds.RequestCancellation(ds.CancellationDisabled))
+func RequestCancellation(c Cancellation) option {
+	return Opt("requestCancellation", string(c))
+}
+
+// ContentType selects the contentType option value.
+type ContentType string
+
+const (
+	ContentTypeJSON ContentType = "json"
+	ContentTypeForm ContentType = "form"
+)
+
+// ContentTypeOpt sets the request body encoding.
+//
+//	ds.Post("/api/todos", ds.ContentTypeOpt(ds.ContentTypeForm))
+func ContentTypeOpt(c ContentType) option {
+	return Opt("contentType", string(c))
+}
+
+// OpenWhenHidden sets whether the SSE connection stays open while the
+// document is hidden (e.g. a backgrounded tab).
+//
+//	ds.Get("/api/updates", ds.OpenWhenHidden(true))
+func OpenWhenHidden(enabled bool) option {
+	return OptRaw("openWhenHidden", strconv.FormatBool(enabled))
+}
+
+// RetryMode selects the retry option value.
+type RetryMode string
+
+const (
+	RetryNever     RetryMode = "never"
+	RetryAlways    RetryMode = "always"
+	RetryOnFailure RetryMode = "onFailure"
+)
+
+// Retry sets when a failed SSE connection is retried.
+//
+//	ds.Get("/api/updates", ds.Retry(ds.RetryAlways))
+func Retry(r RetryMode) option {
+	return Opt("retry", string(r))
+}
+
+// RetryMaxCount sets the maximum number of retry attempts.
+//
+// Panics if n is negative.
+//
+//	ds.Get("/api/updates", ds.RetryMaxCount(5))
+func RetryMaxCount(n int) option {
+	if n < 0 {
+		panic(fmt.Sprintf("ds: retryMaxCount must not be negative, got %d", n))
+	}
+	return OptRaw("retryMaxCount", strconv.Itoa(n))
+}
+
+// RetryScaler sets the backoff multiplier applied between retry attempts.
+//
+// Panics if s is negative.
+//
+//	ds.Get("/api/updates", ds.RetryScaler(1.5))
+func RetryScaler(s float64) option {
+	if s < 0 {
+		panic(fmt.Sprintf("ds: retryScaler must not be negative, got %v", s))
+	}
+	return OptRaw("retryScaler", strconv.FormatFloat(s, 'g', -1, 64))
+}
+
+// RetryMaxWaitMs sets the maximum backoff wait, in milliseconds, between
+// retry attempts.
+//
+// Panics if ms is negative.
+//
+//	ds.Get("/api/updates", ds.RetryMaxWaitMs(30000))
+func RetryMaxWaitMs(ms int) option {
+	if ms < 0 {
+		panic(fmt.Sprintf("ds: retryMaxWaitMs must not be negative, got %d", ms))
+	}
+	return OptRaw("retryMaxWaitMs", strconv.Itoa(ms))
+}
+
+// FilterSignals restricts which signals are sent with the request.
+//
+//	ds.Get("/api/updates", ds.FilterSignals(ds.Filter{Include: "/^user\\./"}))
+func FilterSignals(filter Filter) option {
+	return OptRaw("filterSignals", toFilter(filter))
+}
+
+// Headers sets additional HTTP headers sent with the request.
+//
+//	ds.Post("/api/todos", ds.Headers(map[string]string{"X-CSRF-Token": "abc"}))
+func Headers(headers map[string]string) option {
+	b := sharedBuilderPool.Get().(*strings.Builder)
+	defer func() {
+		b.Reset()
+		sharedBuilderPool.Put(b)
+	}()
+
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(b, "'%s': %q", k, headers[k])
+	}
+	b.WriteByte('}')
+	return OptRaw("headers", b.String())
+}
+
 // ===========================================================================
 // SSE Action Expression Builders
 //
@@ -110,23 +247,81 @@ func Delete(urlFormat string, args ...any) string {
 // ---------------------------------------------------------------------------
 
 // sseAction is the shared builder for all SSE action expressions.
-// It partitions args into fmt.Sprintf format args and sseOption values,
-// then builds the expression string.
+// It partitions args into fmt.Sprintf format args, sseOption values, and
+// queryArg values, then builds the expression string. The assembled URL is
+// escaped before being embedded in the single-quoted JS string literal so
+// that user-controlled format args can't break out of it.
 func sseAction(verb, urlFormat string, args []any) string {
 	var fmtArgs []any
 	var opts []sseOption
+	var query neturl.Values
 	for _, a := range args {
-		if o, ok := a.(sseOption); ok {
-			opts = append(opts, o)
-		} else {
+		switch v := a.(type) {
+		case sseOption:
+			opts = append(opts, v)
+		case queryArg:
+			if query == nil {
+				query = neturl.Values{}
+			}
+			for k, vals := range v.values {
+				query[k] = append(query[k], vals...)
+			}
+		default:
 			fmtArgs = append(fmtArgs, a)
 		}
 	}
-	url := fmt.Sprintf(urlFormat, fmtArgs...)
+	u := fmt.Sprintf(urlFormat, fmtArgs...)
+	if query != nil {
+		sep := "?"
+		if strings.Contains(u, "?") {
+			sep = "&"
+		}
+		u += sep + query.Encode()
+	}
+	u = escapeSingleQuoted(u)
 	if len(opts) == 0 {
-		return fmt.Sprintf("@%s('%s')", verb, url)
+		return fmt.Sprintf("@%s('%s')", verb, u)
+	}
+	return fmt.Sprintf("@%s('%s',%s)", verb, u, buildOpts(opts))
+}
+
+// escapeSingleQuoted escapes characters that would let a user-controlled
+// value break out of a single-quoted JS string literal — the action URL
+// emitted by sseAction, and an Opt value embedded by buildOpts. Backslashes
+// and single quotes are escaped; control characters have no legitimate place
+// in either and are rejected outright.
+func escapeSingleQuoted(s string) string {
+	for _, r := range s {
+		if r < 0x20 || r == 0x7f {
+			panic(fmt.Sprintf("ds: URL or option value must not contain control characters, got %q", s))
+		}
 	}
-	return fmt.Sprintf("@%s('%s',%s)", verb, url, buildOpts(opts))
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `\'`)
+	return s
+}
+
+// queryArg holds query-string parameters to append to an SSE action URL.
+type queryArg struct {
+	values neturl.Values
+}
+
+// Query appends a url.Values query string to the action URL, properly
+// escaped with url.QueryEscape.
+//
+//	ds.Get("/api/search", ds.Query(url.Values{"q": {"hello world"}}))
+//	// -> "@get('/api/search?q=hello+world')"
+func Query(values neturl.Values) any {
+	return queryArg{values: values}
+}
+
+// Q appends a single query parameter to the action URL. Use Query instead
+// when you need multiple values for the same key.
+//
+//	ds.Get("/api/todos", ds.Q("sort", "title"), ds.Q("dir", "asc"))
+//	// -> "@get('/api/todos?dir=asc&sort=title')"
+func Q(key string, value any) any {
+	return queryArg{values: neturl.Values{key: {fmt.Sprint(value)}}}
 }
 
 // buildOpts builds a JavaScript options object from sseOption values.
@@ -146,7 +341,7 @@ func buildOpts(opts []sseOption) string {
 		if o.raw {
 			fmt.Fprintf(b, "%s: %s", o.key, o.value)
 		} else {
-			fmt.Fprintf(b, "%s: '%s'", o.key, o.value)
+			fmt.Fprintf(b, "%s: '%s'", o.key, escapeSingleQuoted(o.value))
 		}
 	}
 	b.WriteByte('}')
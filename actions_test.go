@@ -1,12 +1,13 @@
 package ds_test
 
 import (
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	"go-scheduler/pkg/ds"
+	ds "github.com/Yacobolo/datastar-templ"
 )
 
 // ---------------------------------------------------------------------------
@@ -201,3 +202,145 @@ func TestBuildUpdatesInitURLEquivalent(t *testing.T) {
 		result,
 	)
 }
+
+// ---------------------------------------------------------------------------
+// Typed option constructors
+// ---------------------------------------------------------------------------
+
+func TestTypedOptions(t *testing.T) {
+	t.Run("RequestCancellation", func(t *testing.T) {
+		assert.Equal(t,
+			"@get('/api/updates',{requestCancellation: 'disabled'})",
+			ds.Get("/api/updates", ds.RequestCancellation(ds.CancellationDisabled)),
+		)
+	})
+
+	t.Run("ContentTypeOpt", func(t *testing.T) {
+		assert.Equal(t,
+			"@post('/api/todos',{contentType: 'form'})",
+			ds.Post("/api/todos", ds.ContentTypeOpt(ds.ContentTypeForm)),
+		)
+	})
+
+	t.Run("OpenWhenHidden", func(t *testing.T) {
+		assert.Equal(t,
+			"@get('/api/updates',{openWhenHidden: true})",
+			ds.Get("/api/updates", ds.OpenWhenHidden(true)),
+		)
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		assert.Equal(t,
+			"@get('/api/updates',{retry: 'always'})",
+			ds.Get("/api/updates", ds.Retry(ds.RetryAlways)),
+		)
+	})
+
+	t.Run("RetryMaxCount", func(t *testing.T) {
+		assert.Equal(t,
+			"@get('/api/updates',{retryMaxCount: 5})",
+			ds.Get("/api/updates", ds.RetryMaxCount(5)),
+		)
+	})
+
+	t.Run("RetryMaxCount panics on negative", func(t *testing.T) {
+		assert.Panics(t, func() { ds.RetryMaxCount(-1) })
+	})
+
+	t.Run("RetryScaler", func(t *testing.T) {
+		assert.Equal(t,
+			"@get('/api/updates',{retryScaler: 1.5})",
+			ds.Get("/api/updates", ds.RetryScaler(1.5)),
+		)
+	})
+
+	t.Run("RetryScaler panics on negative", func(t *testing.T) {
+		assert.Panics(t, func() { ds.RetryScaler(-1) })
+	})
+
+	t.Run("RetryMaxWaitMs", func(t *testing.T) {
+		assert.Equal(t,
+			"@get('/api/updates',{retryMaxWaitMs: 30000})",
+			ds.Get("/api/updates", ds.RetryMaxWaitMs(30000)),
+		)
+	})
+
+	t.Run("RetryMaxWaitMs panics on negative", func(t *testing.T) {
+		assert.Panics(t, func() { ds.RetryMaxWaitMs(-1) })
+	})
+
+	t.Run("FilterSignals", func(t *testing.T) {
+		assert.Equal(t,
+			"@get('/api/updates',{filterSignals: {include: /^user\\./}})",
+			ds.Get("/api/updates", ds.FilterSignals(ds.Filter{Include: "/^user\\./"})),
+		)
+	})
+
+	t.Run("Headers", func(t *testing.T) {
+		assert.Equal(t,
+			`@post('/api/todos',{headers: {'X-CSRF-Token': "abc"}})`,
+			ds.Post("/api/todos", ds.Headers(map[string]string{"X-CSRF-Token": "abc"})),
+		)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// URL escaping and query helpers
+// ---------------------------------------------------------------------------
+
+func TestURLEscaping(t *testing.T) {
+	t.Run("single quote in format arg is escaped", func(t *testing.T) {
+		assert.Equal(t,
+			`@get('/api/search?q=o\'brien')`,
+			ds.Get("/api/search?q=%s", "o'brien"),
+		)
+	})
+
+	t.Run("backslash in format arg is escaped", func(t *testing.T) {
+		assert.Equal(t,
+			`@get('/api/files/a\\b')`,
+			ds.Get("/api/files/%s", `a\b`),
+		)
+	})
+
+	t.Run("control character panics", func(t *testing.T) {
+		assert.Panics(t, func() { ds.Get("/api/search?q=%s", "a\nb") })
+	})
+
+	t.Run("single quote in an Opt value is escaped", func(t *testing.T) {
+		assert.Equal(t,
+			`@get('/api/updates',{contentType: 'o\'brien'})`,
+			ds.Get("/api/updates", ds.Opt("contentType", "o'brien")),
+		)
+	})
+}
+
+func TestQuery(t *testing.T) {
+	t.Run("Query with url.Values", func(t *testing.T) {
+		assert.Equal(t,
+			"@get('/api/search?q=hello+world')",
+			ds.Get("/api/search", ds.Query(url.Values{"q": {"hello world"}})),
+		)
+	})
+
+	t.Run("Q appends a single param", func(t *testing.T) {
+		assert.Equal(t,
+			"@get('/api/todos?dir=asc&sort=title')",
+			ds.Get("/api/todos", ds.Q("sort", "title"), ds.Q("dir", "asc")),
+		)
+	})
+
+	t.Run("Query combined with existing query string", func(t *testing.T) {
+		assert.Equal(t,
+			"@get('/api/todos?page=1&sort=title')",
+			ds.Get("/api/todos?page=1", ds.Q("sort", "title")),
+		)
+	})
+
+	t.Run("Query combined with opts", func(t *testing.T) {
+		assert.Equal(t,
+			"@get('/api/todos?sort=title',{openWhenHidden: true})",
+			ds.Get("/api/todos", ds.Q("sort", "title"), ds.OpenWhenHidden(true)),
+		)
+	})
+}
@@ -1,7 +1,8 @@
 package ds
 
 import (
-	"encoding/json"
+	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"sync"
@@ -22,36 +23,68 @@ import (
 type Signal struct {
 	key   string
 	value string
+
+	// raw/hasRaw track the pre-encoding value for signals built from JSON,
+	// so SignalsWith can re-render them with a caller-supplied Encoder
+	// instead of the DefaultEncoder value already used here.
+	raw    any
+	hasRaw bool
 }
 
 // Int creates an integer signal.
 func Int(key string, value int) Signal {
-	return Signal{key, strconv.Itoa(value)}
+	return Signal{key: key, value: strconv.Itoa(value)}
 }
 
 // String creates a string signal (properly quoted for JavaScript).
 func String(key string, value string) Signal {
-	return Signal{key, strconv.Quote(value)}
+	return Signal{key: key, value: strconv.Quote(value)}
 }
 
 // Bool creates a boolean signal.
 func Bool(key string, value bool) Signal {
-	return Signal{key, strconv.FormatBool(value)}
+	return Signal{key: key, value: strconv.FormatBool(value)}
 }
 
 // Float creates a float signal.
 func Float(key string, value float64) Signal {
-	return Signal{key, strconv.FormatFloat(value, 'f', -1, 64)}
+	return Signal{key: key, value: strconv.FormatFloat(value, 'f', -1, 64)}
 }
 
-// JSON creates a signal from any value using JSON marshaling.
-// Use this for complex types like arrays, objects, etc.
+// JSON creates a signal from any value using JSON marshaling via
+// DefaultEncoder, which renders time.Duration/time.Time/big.Int/big.Rat/
+// big.Float more usefully than encoding/json's defaults. Use this for
+// complex types like arrays, objects, etc.
+//
+// Panics if value can't be marshaled; use JSONSafe for the error-returning
+// variant.
 func JSON(key string, value any) Signal {
-	data, err := json.Marshal(value)
+	sig, err := JSONSafe(key, value)
+	if err != nil {
+		panic(err)
+	}
+	return sig
+}
+
+// JSONSafe is the error-returning variant of JSON, for values that didn't
+// come from a literal call site.
+func JSONSafe(key string, value any) (Signal, error) {
+	data, err := DefaultEncoder.Marshal(value)
 	if err != nil {
-		panic("ds: failed to marshal JSON signal: " + err.Error())
+		return Signal{}, fmt.Errorf("ds: failed to marshal JSON signal: %w", err)
+	}
+	return Signal{key: key, value: string(data), raw: value, hasRaw: true}, nil
+}
+
+// JSONTo streams value's JSON encoding (via DefaultEncoder) directly into
+// w, skipping the intermediate []byte JSON/JSONSafe allocate. Use this for
+// large signal payloads (tables, chart series) written straight into a
+// caller-owned strings.Builder or bytes.Buffer.
+func JSONTo(w io.Writer, key string, value any) error {
+	if err := DefaultEncoder.EncodeTo(w, value); err != nil {
+		return fmt.Errorf("ds: failed to marshal JSON signal %q: %w", key, err)
 	}
-	return Signal{key, string(data)}
+	return nil
 }
 
 // Pool for reusing strings.Builder instances
@@ -63,12 +96,56 @@ var signalsBuilderPool = sync.Pool{
 	},
 }
 
-// Signals patches one or more signals using typed helpers.
+// Signals patches one or more signals using typed helpers. Modifiers
+// (ModIfMissing, ModCase+Kebab/Camel/...) can be passed alongside the
+// signals and apply to the data-signals key as a whole.
 //
 //	{ ds.Signals(ds.Int("count", 0), ds.String("msg", "hello"))... }
+//	{ ds.Signals(ds.Int("count", 0), ds.ModIfMissing)... }
 //
 // See https://data-star.dev/reference/attributes#data-signals
-func Signals(signals ...Signal) templ.Attributes {
+func Signals(args ...any) templ.Attributes {
+	var signals []Signal
+	var modifiers []Modifier
+	for _, a := range args {
+		switch v := a.(type) {
+		case Signal:
+			signals = append(signals, v)
+		case Modifier:
+			modifiers = append(modifiers, v)
+		default:
+			panic(fmt.Sprintf("ds: Signals expects Signal or Modifier arguments, got %T", a))
+		}
+	}
+	return signalsAttrs(signals, modifiers)
+}
+
+// SignalsWith is Signals for callers who built their signals with a
+// non-default Encoder: any Signal constructed by JSON/JSONSafe is
+// re-marshaled with enc instead of the DefaultEncoder value it already
+// carries (e.g. to pick up a domain-specific RegisterType hook). Signals
+// built from Int/String/Bool/Float/Struct never go through an Encoder and
+// are passed through unchanged.
+//
+//	ds.SignalsWith(priceEncoder, ds.JSON("price", money))
+func SignalsWith(enc *Encoder, sigs ...Signal) templ.Attributes {
+	resolved := make([]Signal, len(sigs))
+	for i, sig := range sigs {
+		if sig.hasRaw {
+			data, err := enc.Marshal(sig.raw)
+			if err != nil {
+				panic(fmt.Sprintf("ds: failed to marshal JSON signal %q: %v", sig.key, err))
+			}
+			sig.value = string(data)
+		}
+		resolved[i] = sig
+	}
+	return signalsAttrs(resolved, nil)
+}
+
+// signalsAttrs builds the data-signals attribute string shared by Signals
+// and SignalsWith.
+func signalsAttrs(signals []Signal, modifiers []Modifier) templ.Attributes {
 	b := signalsBuilderPool.Get().(*strings.Builder)
 	defer func() {
 		b.Reset()
@@ -103,7 +180,7 @@ func Signals(signals ...Signal) templ.Attributes {
 	}
 	b.WriteByte('}')
 
-	return templ.Attributes{"data-signals": b.String()}
+	return templ.Attributes{plugin(attrSignals, modifiers): b.String()}
 }
 
 // SignalsJSON patches signals using a pre-built JSON string value.
@@ -248,6 +325,9 @@ func OnSignalPatchFilter(filter Filter) templ.Attributes {
 //
 // See https://data-star.dev/reference/attributes#data-bind
 func Bind(name string, modifiers ...Modifier) templ.Attributes {
+	if strictMode.Load() && !validSignalPath(name) {
+		panic(fmt.Sprintf("ds: Bind: invalid signal name %q", name))
+	}
 	return boolAttr(keyed(attrBind, name, modifiers))
 }
 
@@ -0,0 +1,72 @@
+// Command datastar-templ-gen generates refactor-safe signal accessors for a
+// Go struct, turning magic strings like "$count" into state.Count.Ref().
+//
+// Typical usage, via go:generate in the file declaring the struct:
+//
+//	//go:generate datastar-templ-gen -type=State -output=state_signals.go
+//	type State struct {
+//		Count   int    `ds:"count"`
+//		Message string `ds:"message"`
+//	}
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Yacobolo/datastar-templ/gen"
+)
+
+func main() {
+	var (
+		typeName = flag.String("type", "", "name of the struct to generate accessors for (required)")
+		input    = flag.String("input", "", "Go source file declaring -type (default: $GOFILE)")
+		output   = flag.String("output", "", "output file path (default: <type>_signals.go, lowercased)")
+	)
+	flag.Parse()
+
+	if *typeName == "" {
+		fmt.Fprintln(os.Stderr, "datastar-templ-gen: -type is required")
+		os.Exit(2)
+	}
+	if *input == "" {
+		*input = os.Getenv("GOFILE")
+	}
+	if *input == "" {
+		fmt.Fprintln(os.Stderr, "datastar-templ-gen: -input is required outside go:generate")
+		os.Exit(2)
+	}
+
+	if err := run(*typeName, *input, *output); err != nil {
+		fmt.Fprintln(os.Stderr, "datastar-templ-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(typeName, input, output string) error {
+	spec, err := gen.ParseStruct(input, typeName)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		output = fmt.Sprintf("%s_signals.go", lower(typeName))
+	}
+
+	src, err := spec.Generate(spec.SourcePackage)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(output, src, 0o644)
+}
+
+func lower(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= 'A' && c <= 'Z' {
+			b[i] = c + ('a' - 'A')
+		}
+	}
+	return string(b)
+}
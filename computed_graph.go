@@ -0,0 +1,166 @@
+package ds
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/a-h/templ"
+)
+
+// ---------------------------------------------------------------------------
+// ComputedGraph / DepGraph
+//
+// Computed's flat {'total': () => $price * $qty, ...} output gives no way to
+// see which computed signals depend on which, so a diamond-shaped
+// recomputation chain or an accidental cycle between two computed signals
+// only surfaces as a runtime error in the browser. ComputedGraph parses each
+// pair's expr for "$name" references (reusing scanSignalRefs, the same
+// scanner Validate uses) and builds a DepGraph a caller can inspect or debug
+// with before the attributes ever render.
+// ---------------------------------------------------------------------------
+
+// DepGraph is the dependency graph ComputedGraph builds from a set of
+// ComputedPairs: one node per computed signal, with an edge to every signal
+// name ("$name") its expression references.
+type DepGraph struct {
+	order []string
+	deps  map[string][]string
+}
+
+// DependsOn returns the signal names (computed or not) name's expression
+// references, in the order they first appear.
+func (g *DepGraph) DependsOn(name string) []string {
+	return append([]string(nil), g.deps[name]...)
+}
+
+// Topo returns the computed signal names in dependency order: if A's
+// expression references computed signal B, B comes before A.
+func (g *DepGraph) Topo() []string {
+	visited := make(map[string]bool, len(g.order))
+	out := make([]string, 0, len(g.order))
+
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+		for _, dep := range g.deps[name] {
+			if _, isComputed := g.deps[dep]; isComputed {
+				visit(dep)
+			}
+		}
+		out = append(out, name)
+	}
+	for _, name := range g.order {
+		visit(name)
+	}
+	return out
+}
+
+// Dot renders the graph as a Graphviz "digraph computed { ... }", one edge
+// per (computed signal, referenced signal) pair, for pasting into a
+// Graphviz viewer while debugging a reactive chain.
+func (g *DepGraph) Dot() string {
+	var b strings.Builder
+	b.WriteString("digraph computed {\n")
+	for _, name := range g.order {
+		for _, dep := range g.deps[name] {
+			fmt.Fprintf(&b, "  %q -> %q;\n", name, dep)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// ComputedGraph is Computed, but also parses each pair's expr for "$name"
+// references and returns the resulting *DepGraph alongside the same
+// templ.Attributes Computed would produce.
+//
+//	attrs, graph, err := ds.ComputedGraph(
+//		ds.Comp("subtotal", "$price * $qty"),
+//		ds.Comp("total", "$subtotal + $shipping"),
+//	)
+//	graph.Topo()           // -> ["subtotal", "total"]
+//	graph.DependsOn("total") // -> ["subtotal", "shipping"]
+//
+// Returns an error if two or more computed signals form a cycle among
+// themselves (a computed signal's expression, directly or transitively,
+// references itself); referencing a non-computed signal never cycles.
+func ComputedGraph(pairs ...ComputedPair) (templ.Attributes, *DepGraph, error) {
+	g := &DepGraph{deps: make(map[string][]string, len(pairs))}
+	for _, p := range pairs {
+		g.order = append(g.order, p.name)
+		seen := make(map[string]bool)
+		var deps []string
+		for _, ref := range scanSignalRefs(p.expr) {
+			if !seen[ref] {
+				seen[ref] = true
+				deps = append(deps, ref)
+			}
+		}
+		g.deps[p.name] = deps
+	}
+
+	if cycle, ok := findComputedCycle(g); !ok {
+		return nil, nil, fmt.Errorf("ds: ComputedGraph: cycle detected: %s", strings.Join(cycle, " -> "))
+	}
+
+	return Computed(pairs...), g, nil
+}
+
+// findComputedCycle walks only the computed-to-computed edges of g (a
+// computed signal referencing a plain, non-computed signal can never
+// cycle). Returns the cycle path and false if one is found.
+func findComputedCycle(g *DepGraph) ([]string, bool) {
+	const (
+		white = iota
+		gray
+		black
+	)
+	color := make(map[string]int, len(g.order))
+	var path []string
+	var cycle []string
+
+	var visit func(name string) bool
+	visit = func(name string) bool {
+		color[name] = gray
+		path = append(path, name)
+		for _, dep := range g.deps[name] {
+			if _, isComputed := g.deps[dep]; !isComputed {
+				continue
+			}
+			switch color[dep] {
+			case gray:
+				start := indexOfString(path, dep)
+				cycle = append(append([]string(nil), path[start:]...), dep)
+				return true
+			case white:
+				if visit(dep) {
+					return true
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		color[name] = black
+		return false
+	}
+
+	for _, name := range g.order {
+		if color[name] == white {
+			if visit(name) {
+				return cycle, false
+			}
+		}
+	}
+	return nil, true
+}
+
+func indexOfString(s []string, v string) int {
+	for i, x := range s {
+		if x == v {
+			return i
+		}
+	}
+	return -1
+}
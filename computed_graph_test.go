@@ -0,0 +1,98 @@
+package ds_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ds "github.com/Yacobolo/datastar-templ"
+)
+
+func TestComputedGraph(t *testing.T) {
+	t.Run("builds attrs identical to Computed", func(t *testing.T) {
+		attrs, graph, err := ds.ComputedGraph(
+			ds.Comp("subtotal", "$price * $qty"),
+			ds.Comp("total", "$subtotal + $shipping"),
+		)
+		require.NoError(t, err)
+		require.NotNil(t, graph)
+		want := ds.Computed(
+			ds.Comp("subtotal", "$price * $qty"),
+			ds.Comp("total", "$subtotal + $shipping"),
+		)
+		assert.Equal(t, want, attrs)
+	})
+
+	t.Run("DependsOn reports every referenced signal", func(t *testing.T) {
+		_, graph, err := ds.ComputedGraph(
+			ds.Comp("subtotal", "$price * $qty"),
+			ds.Comp("total", "$subtotal + $shipping"),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"price", "qty"}, graph.DependsOn("subtotal"))
+		assert.Equal(t, []string{"subtotal", "shipping"}, graph.DependsOn("total"))
+	})
+
+	t.Run("Topo orders dependencies before dependents", func(t *testing.T) {
+		_, graph, err := ds.ComputedGraph(
+			ds.Comp("total", "$subtotal + $shipping"),
+			ds.Comp("subtotal", "$price * $qty"),
+		)
+		require.NoError(t, err)
+		order := graph.Topo()
+		subIdx := indexOfTest(order, "subtotal")
+		totalIdx := indexOfTest(order, "total")
+		assert.Less(t, subIdx, totalIdx)
+	})
+
+	t.Run("Dot renders one edge per dependency", func(t *testing.T) {
+		_, graph, err := ds.ComputedGraph(ds.Comp("subtotal", "$price * $qty"))
+		require.NoError(t, err)
+		dot := graph.Dot()
+		assert.Contains(t, dot, "digraph computed {")
+		assert.Contains(t, dot, `"subtotal" -> "price"`)
+		assert.Contains(t, dot, `"subtotal" -> "qty"`)
+	})
+
+	t.Run("detects a direct cycle between two computed signals", func(t *testing.T) {
+		_, graph, err := ds.ComputedGraph(
+			ds.Comp("a", "$b + 1"),
+			ds.Comp("b", "$a + 1"),
+		)
+		require.Error(t, err)
+		assert.Nil(t, graph)
+		assert.Contains(t, err.Error(), "cycle")
+	})
+
+	t.Run("detects a transitive cycle", func(t *testing.T) {
+		_, _, err := ds.ComputedGraph(
+			ds.Comp("a", "$b"),
+			ds.Comp("b", "$c"),
+			ds.Comp("c", "$a"),
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cycle")
+	})
+
+	t.Run("a computed referencing a non-computed signal is not a cycle", func(t *testing.T) {
+		_, graph, err := ds.ComputedGraph(ds.Comp("doubled", "$count * 2"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"count"}, graph.DependsOn("doubled"))
+	})
+
+	t.Run("ignores $-prefixed text inside string literals", func(t *testing.T) {
+		_, graph, err := ds.ComputedGraph(ds.Comp("label", `$count + " of $total"`))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"count"}, graph.DependsOn("label"))
+	})
+}
+
+func indexOfTest(s []string, v string) int {
+	for i, x := range s {
+		if x == v {
+			return i
+		}
+	}
+	return -1
+}
@@ -157,6 +157,28 @@ const (
 const (
 	eventLoad  = "load"
 	eventError = "error"
+
+	// HTMLMediaElement (<audio>/<video>) playback events.
+	eventAbort          = "abort"
+	eventCanPlay        = "canplay"
+	eventCanPlayThrough = "canplaythrough"
+	eventDurationChange = "durationchange"
+	eventEnded          = "ended"
+	eventLoadedData     = "loadeddata"
+	eventLoadedMetadata = "loadedmetadata"
+	eventLoadStart      = "loadstart"
+	eventPause          = "pause"
+	eventPlay           = "play"
+	eventPlaying        = "playing"
+	eventProgress       = "progress"
+	eventRateChange     = "ratechange"
+	eventSeeked         = "seeked"
+	eventSeeking        = "seeking"
+	eventStalled        = "stalled"
+	eventSuspend        = "suspend"
+	eventTimeUpdate     = "timeupdate"
+	eventVolumeChange   = "volumechange"
+	eventWaiting        = "waiting"
 )
 
 // Clipboard events.
@@ -166,6 +188,16 @@ const (
 	eventPaste = "paste"
 )
 
+// Composition / input editor events.
+const (
+	eventCompositionStart  = "compositionstart"
+	eventCompositionUpdate = "compositionupdate"
+	eventCompositionEnd    = "compositionend"
+	eventBeforeInput       = "beforeinput"
+	eventSelectionChange   = "selectionchange"
+	eventAuxClick          = "auxclick"
+)
+
 // ---------------------------------------------------------------------------
 // Modifier constants – double-underscore (exported)
 // ---------------------------------------------------------------------------
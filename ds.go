@@ -17,6 +17,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/a-h/templ"
@@ -32,9 +33,11 @@ import (
 type Modifier string
 
 // Filter is used by attributes that accept include/exclude regex patterns.
+// Include/Exclude accept a FilterPattern built with Regex/SignalPrefix/AnyOf/...,
+// or a plain "/.../" string literal for back-compat.
 type Filter struct {
-	Include string
-	Exclude string
+	Include FilterPattern
+	Exclude FilterPattern
 }
 
 // ---------------------------------------------------------------------------
@@ -42,17 +45,29 @@ type Filter struct {
 // ---------------------------------------------------------------------------
 
 // Duration returns a ".{N}ms" modifier tag, rounded to the nearest millisecond.
+// For a duration that round-trips exactly (no millisecond rounding) use
+// DurationFormatted instead.
 //
-// Panics if the duration is negative.
+// Panics if the duration is negative or exceeds DefaultMaxDuration.
 //
 // Example:
 //
 //	ds.OnClick("handler()", ds.ModDebounce, ds.Duration(300*time.Millisecond))
 func Duration(d time.Duration) Modifier {
-	if d < 0 {
-		panic(fmt.Sprintf("ds: duration must not be negative, got %v", d))
+	m, err := DurationSafe(d)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// DurationSafe is the error-returning variant of Duration, for durations
+// that didn't come from a literal call site.
+func DurationSafe(d time.Duration) (Modifier, error) {
+	if err := checkDurationBounds(d, "duration"); err != nil {
+		return "", err
 	}
-	return Modifier(fmt.Sprintf(".%dms", d.Round(time.Millisecond).Milliseconds()))
+	return Modifier(fmt.Sprintf(".%dms", d.Round(time.Millisecond).Milliseconds())), nil
 }
 
 // Ms returns a ".{n}ms" modifier tag. Shorthand for Duration when you have a
@@ -64,10 +79,20 @@ func Duration(d time.Duration) Modifier {
 //
 //	ds.OnInput("search()", ds.ModDebounce, ds.Ms(300))
 func Ms(n int) Modifier {
+	m, err := MsSafe(n)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// MsSafe is the error-returning variant of Ms, for values that didn't come
+// from a literal call site.
+func MsSafe(n int) (Modifier, error) {
 	if n < 0 {
-		panic(fmt.Sprintf("ds: milliseconds must not be negative, got %d", n))
+		return "", fmt.Errorf("ds: milliseconds must not be negative, got %d", n)
 	}
-	return Modifier(fmt.Sprintf(".%dms", n))
+	return Modifier(fmt.Sprintf(".%dms", n)), nil
 }
 
 // Seconds returns a ".{n}s" modifier tag.
@@ -78,10 +103,33 @@ func Ms(n int) Modifier {
 //
 //	ds.OnInterval("poll()", ds.Seconds(5))
 func Seconds(n int) Modifier {
+	m, err := SecondsSafe(n)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// SecondsSafe is the error-returning variant of Seconds, for values that
+// didn't come from a literal call site.
+func SecondsSafe(n int) (Modifier, error) {
 	if n < 0 {
-		panic(fmt.Sprintf("ds: seconds must not be negative, got %d", n))
+		return "", fmt.Errorf("ds: seconds must not be negative, got %d", n)
 	}
-	return Modifier(fmt.Sprintf(".%ds", n))
+	return Modifier(fmt.Sprintf(".%ds", n)), nil
+}
+
+// checkDurationBounds applies the same default [0, DefaultMaxDuration]
+// window DurationFormatted uses, so Duration and DurationFormatted reject
+// out-of-range values the same way.
+func checkDurationBounds(d time.Duration, label string) error {
+	if d < 0 {
+		return fmt.Errorf("ds: %s must not be negative, got %v", label, d)
+	}
+	if d > DefaultMaxDuration {
+		return fmt.Errorf("ds: %s exceeds the maximum supported duration (%v), got %v", label, DefaultMaxDuration, d)
+	}
+	return nil
 }
 
 // Threshold returns a visibility percentage modifier tag for the __threshold modifier.
@@ -92,20 +140,42 @@ func Seconds(n int) Modifier {
 // Example:
 //
 //	ds.OnIntersect("loadMore()", ds.ModThreshold, ds.Threshold(0.5))  // 50% visible
+//
+// See also Thresholds for a progressive list of visibility percentages, and
+// ThresholdExpr for a small every(step)/steps(n) DSL that builds one.
 func Threshold(t float64) Modifier {
-	if t <= 0 || t > 1 {
-		panic(fmt.Sprintf("ds: threshold must be between 0.0 (exclusive) and 1.0 (inclusive), got %v", t))
+	m, err := ThresholdSafe(t)
+	if err != nil {
+		panic(err)
 	}
-	if t == 1 {
-		return Modifier(".100")
+	return m
+}
+
+// ThresholdSafe is the error-returning variant of Threshold, for values that
+// didn't come from a literal call site.
+func ThresholdSafe(t float64) (Modifier, error) {
+	digits, err := thresholdDigits(t)
+	if err != nil {
+		return "", err
 	}
-	return Modifier(strings.TrimPrefix(fmt.Sprintf("%.2f", t), "0"))
+	return Modifier("." + digits), nil
 }
 
 // ---------------------------------------------------------------------------
 // Internal helpers
 // ---------------------------------------------------------------------------
 
+// sharedBuilderPool is reused by helpers that build a single short
+// concatenated string (mods here; Headers and buildOpts in actions.go) and
+// don't warrant a dedicated pool of their own.
+var sharedBuilderPool = sync.Pool{
+	New: func() interface{} {
+		b := new(strings.Builder)
+		b.Grow(64)
+		return b
+	},
+}
+
 // mods concatenates modifiers into a single string.
 func mods(modifiers []Modifier) string {
 	if len(modifiers) == 0 {
@@ -126,21 +196,25 @@ func mods(modifiers []Modifier) string {
 
 // on builds "data-on:{event}{modifiers}".
 func on(event string, modifiers []Modifier) string {
+	checkModifiers("on:"+event, modifiers)
 	return prefixOn + event + mods(modifiers)
 }
 
 // plugin builds "data-{name}{modifiers}" for plugin-based attributes (hyphenated, not colon).
 func plugin(name string, modifiers []Modifier) string {
+	checkModifiers(name, modifiers)
 	return prefix + name + mods(modifiers)
 }
 
 // keyed builds "data-{name}:{key}{modifiers}".
 func keyed(name, key string, modifiers []Modifier) string {
+	checkModifiers(name, modifiers)
 	return prefix + name + sepColon + key + mods(modifiers)
 }
 
 // val returns templ.Attributes with a single key-value pair.
 func val(name, value string) templ.Attributes {
+	checkExpr(name, value)
 	return templ.Attributes{name: value}
 }
 
@@ -230,3 +304,56 @@ func Merge(attrs ...templ.Attributes) templ.Attributes {
 	}
 	return m
 }
+
+// MergeStrategy controls how MergeWith resolves a data-* key produced by
+// more than one input.
+type MergeStrategy int
+
+const (
+	// LastWins keeps the value from the last attrs argument that set the
+	// key. This is what Merge does unconditionally.
+	LastWins MergeStrategy = iota
+	// ErrorOnConflict fails with an error naming the first duplicate key
+	// encountered.
+	ErrorOnConflict
+	// ConcatSemicolon joins conflicting string values with "; ", useful for
+	// attributes like data-effect where multiple expressions can coexist.
+	ConcatSemicolon
+)
+
+// MergeWith combines attrs like Merge, but lets the caller choose how a key
+// produced by more than one input is resolved instead of always keeping the
+// last value.
+//
+//	ds.MergeWith(ds.ErrorOnConflict, ds.Show("$first"), ds.Show("$second"))
+//	// -> nil, error: ds: conflicting attribute "data-show"
+func MergeWith(strategy MergeStrategy, attrs ...templ.Attributes) (templ.Attributes, error) {
+	size := 0
+	for _, a := range attrs {
+		size += len(a)
+	}
+	m := make(templ.Attributes, size)
+	for _, a := range attrs {
+		for k, v := range a {
+			existing, ok := m[k]
+			if !ok {
+				m[k] = v
+				continue
+			}
+			switch strategy {
+			case ErrorOnConflict:
+				return nil, fmt.Errorf("ds: conflicting attribute %q", k)
+			case ConcatSemicolon:
+				es, ok1 := existing.(string)
+				vs, ok2 := v.(string)
+				if !ok1 || !ok2 {
+					return nil, fmt.Errorf("ds: cannot concatenate non-string attribute %q", k)
+				}
+				m[k] = es + "; " + vs
+			default: // LastWins
+				m[k] = v
+			}
+		}
+	}
+	return m, nil
+}
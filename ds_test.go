@@ -141,6 +141,33 @@ func TestMerge(t *testing.T) {
 	})
 }
 
+func TestMergeWith(t *testing.T) {
+	t.Run("LastWins matches Merge", func(t *testing.T) {
+		result, err := ds.MergeWith(ds.LastWins, ds.Show("$first"), ds.Show("$second"))
+		require.NoError(t, err)
+		assert.Equal(t, "$second", result["data-show"])
+	})
+
+	t.Run("ErrorOnConflict fails on duplicate key", func(t *testing.T) {
+		_, err := ds.MergeWith(ds.ErrorOnConflict, ds.Show("$first"), ds.Show("$second"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "data-show")
+	})
+
+	t.Run("ErrorOnConflict passes distinct keys", func(t *testing.T) {
+		result, err := ds.MergeWith(ds.ErrorOnConflict, ds.Show("$open"), ds.OnClick("close()"))
+		require.NoError(t, err)
+		assert.Equal(t, "$open", result["data-show"])
+		assert.Equal(t, "close()", result["data-on:click"])
+	})
+
+	t.Run("ConcatSemicolon joins conflicting string values", func(t *testing.T) {
+		result, err := ds.MergeWith(ds.ConcatSemicolon, ds.Effect("$a = 1"), ds.Effect("$b = 2"))
+		require.NoError(t, err)
+		assert.Equal(t, "$a = 1; $b = 2", result["data-effect"])
+	})
+}
+
 // ---------------------------------------------------------------------------
 // DOM Event Functions â€“ data-on:{event}
 // ---------------------------------------------------------------------------
@@ -262,10 +289,36 @@ func TestAllDOMEvents(t *testing.T) {
 		// Media
 		{"OnLoad", ds.OnLoad, "data-on:load"},
 		{"OnError", ds.OnError, "data-on:error"},
+		{"OnAbort", ds.OnAbort, "data-on:abort"},
+		{"OnCanPlay", ds.OnCanPlay, "data-on:canplay"},
+		{"OnCanPlayThrough", ds.OnCanPlayThrough, "data-on:canplaythrough"},
+		{"OnDurationChange", ds.OnDurationChange, "data-on:durationchange"},
+		{"OnEnded", ds.OnEnded, "data-on:ended"},
+		{"OnLoadedData", ds.OnLoadedData, "data-on:loadeddata"},
+		{"OnLoadedMetadata", ds.OnLoadedMetadata, "data-on:loadedmetadata"},
+		{"OnLoadStart", ds.OnLoadStart, "data-on:loadstart"},
+		{"OnPause", ds.OnPause, "data-on:pause"},
+		{"OnPlay", ds.OnPlay, "data-on:play"},
+		{"OnPlaying", ds.OnPlaying, "data-on:playing"},
+		{"OnProgress", ds.OnProgress, "data-on:progress"},
+		{"OnRateChange", ds.OnRateChange, "data-on:ratechange"},
+		{"OnSeeked", ds.OnSeeked, "data-on:seeked"},
+		{"OnSeeking", ds.OnSeeking, "data-on:seeking"},
+		{"OnStalled", ds.OnStalled, "data-on:stalled"},
+		{"OnSuspend", ds.OnSuspend, "data-on:suspend"},
+		{"OnTimeUpdate", ds.OnTimeUpdate, "data-on:timeupdate"},
+		{"OnVolumeChange", ds.OnVolumeChange, "data-on:volumechange"},
+		{"OnWaiting", ds.OnWaiting, "data-on:waiting"},
 		// Clipboard
 		{"OnCopy", ds.OnCopy, "data-on:copy"},
 		{"OnCut", ds.OnCut, "data-on:cut"},
 		{"OnPaste", ds.OnPaste, "data-on:paste"},
+		// Composition / input editor
+		{"OnCompositionStart", ds.OnCompositionStart, "data-on:compositionstart"},
+		{"OnCompositionUpdate", ds.OnCompositionUpdate, "data-on:compositionupdate"},
+		{"OnCompositionEnd", ds.OnCompositionEnd, "data-on:compositionend"},
+		{"OnBeforeInput", ds.OnBeforeInput, "data-on:beforeinput"},
+		{"OnAuxClick", ds.OnAuxClick, "data-on:auxclick"},
 	}
 
 	for _, tt := range tests {
@@ -277,6 +330,20 @@ func TestAllDOMEvents(t *testing.T) {
 	}
 }
 
+func TestOnSelectionChange(t *testing.T) {
+	t.Run("always window-scoped", func(t *testing.T) {
+		attrs := ds.OnSelectionChange("$selection = document.getSelection().toString()")
+		require.Len(t, attrs, 1)
+		assert.Equal(t, "$selection = document.getSelection().toString()", attrs["data-on:selectionchange__window"])
+	})
+
+	t.Run("window modifier not duplicated by caller", func(t *testing.T) {
+		attrs := ds.OnSelectionChange("$x = 1", ds.ModOnce)
+		require.Len(t, attrs, 1)
+		assert.Equal(t, "$x = 1", attrs["data-on:selectionchange__window__once"])
+	})
+}
+
 func TestOnEvent(t *testing.T) {
 	t.Run("custom event", func(t *testing.T) {
 		attrs := ds.OnEvent("table-select", "$selected = evt.detail.ids")
@@ -404,18 +471,17 @@ func TestSignals(t *testing.T) {
 		assert.Equal(t, `{foo: 1}`, attrs["data-signals"])
 	})
 
-	// TODO: Add modifier support to new Signals API
-	// t.Run("with ifmissing modifier", func(t *testing.T) {
-	// 	attrs := ds.Signals(ds.Int("foo", 1), ds.ModIfMissing)
-	// 	require.Len(t, attrs, 1)
-	// 	assert.Equal(t, `{foo: 1}`, attrs["data-signals__ifmissing"])
-	// })
+	t.Run("with ifmissing modifier", func(t *testing.T) {
+		attrs := ds.Signals(ds.Int("foo", 1), ds.ModIfMissing)
+		require.Len(t, attrs, 1)
+		assert.Equal(t, `{foo: 1}`, attrs["data-signals__ifmissing"])
+	})
 
-	// t.Run("with case modifier", func(t *testing.T) {
-	// 	attrs := ds.Signals(ds.Int("foo", 1), ds.ModCase, ds.Kebab)
-	// 	require.Len(t, attrs, 1)
-	// 	assert.Equal(t, `{foo: 1}`, attrs["data-signals__case.kebab"])
-	// })
+	t.Run("with case modifier", func(t *testing.T) {
+		attrs := ds.Signals(ds.Int("foo", 1), ds.ModCase, ds.Kebab)
+		require.Len(t, attrs, 1)
+		assert.Equal(t, `{foo: 1}`, attrs["data-signals__case.kebab"])
+	})
 
 	t.Run("multiple signals with different types", func(t *testing.T) {
 		attrs := ds.Signals(
@@ -451,15 +517,15 @@ func TestSignalKey(t *testing.T) {
 
 func TestComputed(t *testing.T) {
 	t.Run("single", func(t *testing.T) {
-		attrs := ds.Computed(ds.Pair("total", "$price * $qty"))
+		attrs := ds.Computed(ds.Comp("total", "$price * $qty"))
 		require.Len(t, attrs, 1)
 		assert.Equal(t, "{'total': () => $price * $qty}", attrs["data-computed"])
 	})
 
 	t.Run("multiple", func(t *testing.T) {
 		attrs := ds.Computed(
-			ds.Pair("total", "$price * $qty"),
-			ds.Pair("tax", "$total * 0.1"),
+			ds.Comp("total", "$price * $qty"),
+			ds.Comp("tax", "$total * 0.1"),
 		)
 		require.Len(t, attrs, 1)
 		assert.Equal(t, "{'total': () => $price * $qty, 'tax': () => $total * 0.1}", attrs["data-computed"])
@@ -476,15 +542,15 @@ func TestComputedKey(t *testing.T) {
 
 func TestClass(t *testing.T) {
 	t.Run("single pair", func(t *testing.T) {
-		attrs := ds.Class(ds.Pair("hidden", "$isHidden"))
+		attrs := ds.Class(ds.C("hidden", "$isHidden"))
 		require.Len(t, attrs, 1)
 		assert.Equal(t, "{'hidden': $isHidden}", attrs["data-class"])
 	})
 
 	t.Run("multiple pairs", func(t *testing.T) {
 		attrs := ds.Class(
-			ds.Pair("hidden", "$isHidden"),
-			ds.Pair("font-bold", "$isBold"),
+			ds.C("hidden", "$isHidden"),
+			ds.C("font-bold", "$isBold"),
 		)
 		require.Len(t, attrs, 1)
 		assert.Equal(t, "{'hidden': $isHidden, 'font-bold': $isBold}", attrs["data-class"])
@@ -509,15 +575,15 @@ func TestClassKey(t *testing.T) {
 
 func TestAttr(t *testing.T) {
 	t.Run("single pair", func(t *testing.T) {
-		attrs := ds.Attr(ds.Pair("title", "$tooltip"))
+		attrs := ds.Attr(ds.A("title", "$tooltip"))
 		require.Len(t, attrs, 1)
 		assert.Equal(t, "{'title': $tooltip}", attrs["data-attr"])
 	})
 
 	t.Run("multiple pairs", func(t *testing.T) {
 		attrs := ds.Attr(
-			ds.Pair("title", "$tooltip"),
-			ds.Pair("disabled", "$loading"),
+			ds.A("title", "$tooltip"),
+			ds.A("disabled", "$loading"),
 		)
 		require.Len(t, attrs, 1)
 		assert.Equal(t, "{'title': $tooltip, 'disabled': $loading}", attrs["data-attr"])
@@ -548,15 +614,15 @@ func TestAttrKey(t *testing.T) {
 
 func TestStyle(t *testing.T) {
 	t.Run("single pair", func(t *testing.T) {
-		attrs := ds.Style(ds.Pair("display", "$hiding && 'none'"))
+		attrs := ds.Style(ds.S("display", "$hiding && 'none'"))
 		require.Len(t, attrs, 1)
 		assert.Equal(t, "{'display': $hiding && 'none'}", attrs["data-style"])
 	})
 
 	t.Run("multiple pairs", func(t *testing.T) {
 		attrs := ds.Style(
-			ds.Pair("display", "'none'"),
-			ds.Pair("color", "'red'"),
+			ds.S("display", "'none'"),
+			ds.S("color", "'red'"),
 		)
 		require.Len(t, attrs, 1)
 		assert.Equal(t, "{'display': 'none', 'color': 'red'}", attrs["data-style"])
@@ -1188,11 +1254,10 @@ func TestModifierCombinations(t *testing.T) {
 		assert.Equal(t, true, attrs["data-bind:my-signal__case.camel"])
 	})
 
-	// TODO: Add modifier support to new Signals API
-	// t.Run("signals with case kebab", func(t *testing.T) {
-	// 	attrs := ds.Signals(ds.Int("mySignal", 1), ds.ModCase, ds.Kebab)
-	// 	assert.Contains(t, attrs, "data-signals__case.kebab")
-	// })
+	t.Run("signals with case kebab", func(t *testing.T) {
+		attrs := ds.Signals(ds.Int("mySignal", 1), ds.ModCase, ds.Kebab)
+		assert.Contains(t, attrs, "data-signals__case.kebab")
+	})
 
 	t.Run("class with case camel", func(t *testing.T) {
 		attrs := ds.ClassKey("my-class", "$active", ds.ModCase, ds.Camel)
@@ -1455,7 +1520,7 @@ func TestBoundaryConditions(t *testing.T) {
 
 	t.Run("threshold edge case 0.999", func(t *testing.T) {
 		threshold := ds.Threshold(0.999)
-		assert.Equal(t, "1.00", string(threshold)) // Rounds to 2 decimal places
+		assert.Equal(t, ".100", string(threshold)) // Rounds to the same modifier as t == 1
 	})
 
 	t.Run("duration zero milliseconds", func(t *testing.T) {
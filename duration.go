@@ -0,0 +1,274 @@
+package ds
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Duration formatting
+//
+// Duration/Ms/Seconds above exist to build ".Nms"/".Ns" modifier tags and
+// always round to a single unit, which is fine for a debounce suffix but
+// throws away information (Duration(500*time.Microsecond) silently becomes
+// ".1ms"). DurationFormatted is a general-purpose formatter for anywhere a
+// duration needs to round-trip exactly: it picks the coarsest unit that
+// represents the value exactly, the way protobuf's JSON Duration marshaller
+// picks 0/3/6/9 fractional digits rather than always printing nanoseconds.
+// ---------------------------------------------------------------------------
+
+// Unit is a duration unit DurationFormatted can auto-select or be forced to
+// use via WithUnit.
+type Unit string
+
+const (
+	UnitNs Unit = "ns"
+	UnitUs Unit = "us"
+	UnitMs Unit = "ms"
+	UnitS  Unit = "s"
+	UnitM  Unit = "m"
+	UnitH  Unit = "h"
+)
+
+// durationUnits lists units from coarsest to finest; this is the order
+// autoDurationUnit tries them in, and ns is last so the search always
+// terminates (a whole number of nanoseconds is always exact).
+var durationUnits = []struct {
+	unit  Unit
+	scale int64 // nanoseconds per unit
+}{
+	{UnitH, int64(time.Hour)},
+	{UnitM, int64(time.Minute)},
+	{UnitS, int64(time.Second)},
+	{UnitMs, int64(time.Millisecond)},
+	{UnitUs, int64(time.Microsecond)},
+	{UnitNs, int64(time.Nanosecond)},
+}
+
+func unitScale(u Unit) (int64, error) {
+	for _, e := range durationUnits {
+		if e.unit == u {
+			return e.scale, nil
+		}
+	}
+	return 0, fmt.Errorf("ds: unknown duration unit %q", u)
+}
+
+// autoDurationUnit picks the coarsest unit that represents d with no
+// remainder, falling back to UnitNs (always exact, since d is itself a
+// whole number of nanoseconds).
+func autoDurationUnit(d time.Duration) Unit {
+	for _, e := range durationUnits {
+		if int64(d)%e.scale == 0 {
+			return e.unit
+		}
+	}
+	return UnitNs
+}
+
+// DefaultMaxDuration is the default upper bound DurationFormatted accepts.
+// Protobuf's Duration allows up to 315,576,000,000s (~10,000 years), but
+// time.Duration is an int64 count of nanoseconds and tops out around 292
+// years, so the practical default ceiling is the largest representable
+// time.Duration. Pass WithRange to use a tighter window.
+const DefaultMaxDuration = time.Duration(math.MaxInt64)
+
+type durationConfig struct {
+	unit          Unit
+	hasPrecision  bool
+	precision     int
+	allowNegative bool
+	hasRange      bool
+	min, max      time.Duration
+}
+
+// DurationOpt configures DurationFormatted/DurationFormattedSafe.
+type DurationOpt func(*durationConfig)
+
+// WithUnit forces DurationFormatted to use u instead of auto-selecting the
+// coarsest unit that represents the value exactly.
+func WithUnit(u Unit) DurationOpt {
+	return func(c *durationConfig) { c.unit = u }
+}
+
+// WithPrecision caps the number of fractional digits DurationFormatted
+// emits, rounding half away from zero instead of returning an error when
+// the value isn't exact at that precision. n is snapped up to the nearest
+// supported digit count: 0, 3, 6, or 9.
+func WithPrecision(n int) DurationOpt {
+	return func(c *durationConfig) { c.hasPrecision = true; c.precision = n }
+}
+
+// WithAllowNegative permits negative durations, e.g. a debounce
+// leading-edge offset measured as a negative delay. Without it,
+// DurationFormatted rejects d < 0 the same way Duration/Ms/Seconds do.
+func WithAllowNegative() DurationOpt {
+	return func(c *durationConfig) { c.allowNegative = true }
+}
+
+// WithRange overrides the default [0, DefaultMaxDuration] acceptance window.
+func WithRange(min, max time.Duration) DurationOpt {
+	return func(c *durationConfig) { c.hasRange = true; c.min = min; c.max = max }
+}
+
+// DurationFormatted renders d as "{whole}[.{frac}]{unit}", choosing the
+// coarsest unit and fewest fractional digits (0, 3, 6, or 9) that represent
+// d exactly, mirroring how protobuf's JSON Duration marshaller picks
+// fractional precision:
+//
+//	ds.DurationFormatted(500*time.Microsecond)                  // -> "500us"
+//	ds.DurationFormatted(1500*time.Microsecond)                 // -> "1500us" (exact; ms would need a fraction)
+//	ds.DurationFormatted(300*time.Millisecond)                  // -> "300ms"
+//	ds.DurationFormatted(90*time.Minute, ds.WithUnit(ds.UnitH)) // -> "1.500h"
+//
+// Panics if d is negative (unless WithAllowNegative is given), outside the
+// configured range, or can't be represented exactly at the chosen unit
+// within 9 fractional digits (use WithPrecision to round instead).
+func DurationFormatted(d time.Duration, opts ...DurationOpt) string {
+	s, err := DurationFormattedSafe(d, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// DurationFormattedSafe is the error-returning variant of DurationFormatted,
+// for durations that didn't come from a literal call site.
+func DurationFormattedSafe(d time.Duration, opts ...DurationOpt) (string, error) {
+	var cfg durationConfig
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	min, max := time.Duration(0), DefaultMaxDuration
+	if cfg.allowNegative {
+		min = -DefaultMaxDuration
+	}
+	if cfg.hasRange {
+		min, max = cfg.min, cfg.max
+	}
+	if d < 0 && !cfg.allowNegative {
+		return "", fmt.Errorf("ds: duration must not be negative, got %v", d)
+	}
+	if d < min || d > max {
+		return "", fmt.Errorf("ds: duration %v is outside the allowed range [%v, %v]", d, min, max)
+	}
+
+	neg := d < 0
+	abs := d
+	if neg {
+		abs = -abs
+	}
+
+	unit := cfg.unit
+	if unit == "" {
+		if abs == 0 {
+			unit = UnitS
+		} else {
+			unit = autoDurationUnit(abs)
+		}
+	}
+	scale, err := unitScale(unit)
+	if err != nil {
+		return "", err
+	}
+
+	whole := int64(abs) / scale
+	rem := int64(abs) % scale
+
+	digits := 0
+	if cfg.hasPrecision {
+		digits = snapPrecision(cfg.precision)
+	} else if rem != 0 {
+		digits, err = exactFractionDigits(rem, scale)
+		if err != nil {
+			return "", fmt.Errorf("ds: %s at unit %q (use WithPrecision to round instead)", err, unit)
+		}
+	}
+
+	carry, frac := roundFraction(rem, scale, digits)
+	whole += carry
+
+	return formatDuration(neg, whole, digits, frac, unit), nil
+}
+
+// snapPrecision rounds n up to the nearest digit count DurationFormatted
+// supports: 0, 3, 6, or 9.
+func snapPrecision(n int) int {
+	switch {
+	case n <= 0:
+		return 0
+	case n <= 3:
+		return 3
+	case n <= 6:
+		return 6
+	default:
+		return 9
+	}
+}
+
+// exactFractionDigits finds the smallest digit count in {0, 3, 6, 9} at
+// which rem/scale (rem in [0, scale)) has no rounding error, or an error if
+// none of them do.
+func exactFractionDigits(rem, scale int64) (int, error) {
+	for _, k := range [3]int{3, 6, 9} {
+		num := new(big.Int).Mul(big.NewInt(rem), pow10(k))
+		if new(big.Int).Mod(num, big.NewInt(scale)).Sign() == 0 {
+			return k, nil
+		}
+	}
+	return 0, fmt.Errorf("duration requires more than 9 fractional digits to represent exactly")
+}
+
+// roundFraction rounds rem/scale to digits fractional digits (half away
+// from zero), reporting a whole-unit carry if rounding reaches 1.0.
+func roundFraction(rem, scale int64, digits int) (carry int64, frac *big.Int) {
+	if rem == 0 {
+		return 0, big.NewInt(0)
+	}
+	pow := pow10(digits)
+	num := new(big.Int).Mul(big.NewInt(rem), pow)
+	rounded := divRoundHalfUp(num, big.NewInt(scale))
+	if rounded.Cmp(pow) >= 0 {
+		return 1, big.NewInt(0)
+	}
+	return 0, rounded
+}
+
+// divRoundHalfUp divides two non-negative big.Ints, rounding half away from
+// zero, using exact integer arithmetic so large scales (e.g. hours) never
+// lose precision the way a float64 division would.
+func divRoundHalfUp(num, den *big.Int) *big.Int {
+	sum := new(big.Int).Lsh(num, 1)
+	sum.Add(sum, den)
+	twoDen := new(big.Int).Lsh(den, 1)
+	return sum.Div(sum, twoDen)
+}
+
+// pow10 returns 10^n as a big.Int.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// formatDuration assembles the final "{whole}[.{frac}]{unit}" string.
+func formatDuration(neg bool, whole int64, digits int, frac *big.Int, unit Unit) string {
+	var b strings.Builder
+	if neg {
+		b.WriteByte('-')
+	}
+	b.WriteString(strconv.FormatInt(whole, 10))
+	if digits > 0 {
+		b.WriteByte('.')
+		s := frac.String()
+		if pad := digits - len(s); pad > 0 {
+			b.WriteString(strings.Repeat("0", pad))
+		}
+		b.WriteString(s)
+	}
+	b.WriteString(string(unit))
+	return b.String()
+}
@@ -0,0 +1,95 @@
+package ds_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ds "github.com/Yacobolo/datastar-templ"
+)
+
+func TestDurationFormatted(t *testing.T) {
+	t.Run("zero", func(t *testing.T) {
+		assert.Equal(t, "0s", ds.DurationFormatted(0))
+	})
+
+	t.Run("exact in microseconds", func(t *testing.T) {
+		assert.Equal(t, "500us", ds.DurationFormatted(500*time.Microsecond))
+	})
+
+	t.Run("prefers the coarsest unit that still needs no fraction", func(t *testing.T) {
+		// 1500us is not a whole number of ms, but it is a whole number of
+		// us, so auto-selection stops there rather than emitting "1.500ms".
+		assert.Equal(t, "1500us", ds.DurationFormatted(1500*time.Microsecond))
+	})
+
+	t.Run("exact in milliseconds", func(t *testing.T) {
+		assert.Equal(t, "300ms", ds.DurationFormatted(300*time.Millisecond))
+	})
+
+	t.Run("falls back to nanoseconds when nothing coarser divides evenly", func(t *testing.T) {
+		assert.Equal(t, "1234567ns", ds.DurationFormatted(1234567*time.Nanosecond))
+	})
+
+	t.Run("exact in seconds", func(t *testing.T) {
+		assert.Equal(t, "5s", ds.DurationFormatted(5*time.Second))
+	})
+
+	t.Run("exact in minutes", func(t *testing.T) {
+		assert.Equal(t, "2m", ds.DurationFormatted(2*time.Minute))
+	})
+
+	t.Run("exact in hours", func(t *testing.T) {
+		assert.Equal(t, "1h", ds.DurationFormatted(time.Hour))
+	})
+
+	t.Run("panics on negative", func(t *testing.T) {
+		assert.Panics(t, func() { ds.DurationFormatted(-time.Second) })
+	})
+
+	t.Run("panics when forced unit can't represent it within 9 digits", func(t *testing.T) {
+		assert.Panics(t, func() {
+			ds.DurationFormatted(time.Nanosecond, ds.WithUnit(ds.UnitH))
+		})
+	})
+}
+
+func TestDurationFormattedOptions(t *testing.T) {
+	t.Run("WithUnit forces a unit", func(t *testing.T) {
+		assert.Equal(t, "1.500h", ds.DurationFormatted(90*time.Minute, ds.WithUnit(ds.UnitH)))
+	})
+
+	t.Run("WithUnit adds fractional digits to stay exact", func(t *testing.T) {
+		got, err := ds.DurationFormattedSafe(1500*time.Microsecond, ds.WithUnit(ds.UnitS))
+		require.NoError(t, err)
+		assert.Equal(t, "0.001500s", got)
+	})
+
+	t.Run("WithPrecision rounds instead of erroring", func(t *testing.T) {
+		got := ds.DurationFormatted(1234567*time.Nanosecond, ds.WithUnit(ds.UnitMs), ds.WithPrecision(3))
+		assert.Equal(t, "1.235ms", got)
+	})
+
+	t.Run("WithPrecision snaps up to the next supported digit count", func(t *testing.T) {
+		got := ds.DurationFormatted(1234567*time.Nanosecond, ds.WithUnit(ds.UnitMs), ds.WithPrecision(1))
+		assert.Equal(t, "1.235ms", got)
+	})
+
+	t.Run("WithAllowNegative permits negative durations", func(t *testing.T) {
+		assert.Equal(t, "-100ms", ds.DurationFormatted(-100*time.Millisecond, ds.WithAllowNegative()))
+	})
+
+	t.Run("WithRange rejects values outside the window", func(t *testing.T) {
+		_, err := ds.DurationFormattedSafe(time.Hour, ds.WithRange(0, time.Minute))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "outside the allowed range")
+	})
+
+	t.Run("unknown unit fails", func(t *testing.T) {
+		_, err := ds.DurationFormattedSafe(time.Second, ds.WithUnit(ds.Unit("fortnight")))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown duration unit")
+	})
+}
@@ -0,0 +1,320 @@
+package ds
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Streaming JSON encoder
+//
+// ds.JSON leans on encoding/json.Marshal, which renders time.Duration as a
+// bare nanosecond integer and *big.Int as a JSON number — both lose
+// information once they cross into JS, where numbers are float64 and
+// integers above 2^53 silently round. Encoder lets callers register per-type hooks, and
+// DefaultEncoder ships with ones for the types above, applied wherever they
+// appear in a value's tree (top-level or nested in a struct/map/slice).
+// ---------------------------------------------------------------------------
+
+// Encoder renders Go values to JSON for signal payloads, consulting
+// per-type hooks registered with RegisterType before falling back to
+// encoding/json for anything unregistered.
+type Encoder struct {
+	hooks map[reflect.Type]func(any) ([]byte, error)
+}
+
+// NewEncoder returns an Encoder with no hooks registered. Most callers want
+// DefaultEncoder, which is pre-loaded with time.Duration/time.Time/big.*
+// hooks; use NewEncoder when you need a clean slate.
+func NewEncoder() *Encoder {
+	return &Encoder{hooks: make(map[reflect.Type]func(any) ([]byte, error))}
+}
+
+// RegisterType installs fn as the encoder for values of exactly type t,
+// overriding both encoding/json's default rendering and any hook
+// previously registered for t. fn must return valid JSON.
+//
+//	enc.RegisterType(reflect.TypeOf(decimal.Decimal{}), func(v any) ([]byte, error) {
+//		return json.Marshal(v.(decimal.Decimal).String())
+//	})
+func (e *Encoder) RegisterType(t reflect.Type, fn func(any) ([]byte, error)) {
+	e.hooks[t] = fn
+}
+
+// Marshal renders v to JSON, applying registered type hooks wherever a
+// matching value appears in v's tree.
+func (e *Encoder) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := e.EncodeTo(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// EncodeTo streams v's JSON encoding into w the way Marshal does, but
+// without the intermediate []byte — use this for large payloads (tables,
+// chart series) written straight into a caller-owned strings.Builder or
+// bytes.Buffer.
+func (e *Encoder) EncodeTo(w io.Writer, v any) error {
+	if v == nil {
+		_, err := io.WriteString(w, "null")
+		return err
+	}
+	return e.encodeValue(w, reflect.ValueOf(v))
+}
+
+func (e *Encoder) encodeValue(w io.Writer, v reflect.Value) error {
+	if !v.IsValid() {
+		_, err := io.WriteString(w, "null")
+		return err
+	}
+	if v.CanInterface() {
+		if fn, ok := e.hooks[v.Type()]; ok {
+			data, err := fn(v.Interface())
+			if err != nil {
+				return fmt.Errorf("ds: failed to marshal JSON signal: %w", err)
+			}
+			_, err = w.Write(data)
+			return err
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			_, err := io.WriteString(w, "null")
+			return err
+		}
+		return e.encodeValue(w, v.Elem())
+
+	case reflect.Struct:
+		return e.encodeStruct(w, v)
+
+	case reflect.Map:
+		return e.encodeMap(w, v)
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			_, err := io.WriteString(w, "null")
+			return err
+		}
+		if _, err := io.WriteString(w, "["); err != nil {
+			return err
+		}
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			if err := e.encodeValue(w, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		_, err := io.WriteString(w, "]")
+		return err
+
+	default:
+		// No hook and not a container we walk manually: defer to
+		// encoding/json, which covers primitives, strings, and any type
+		// that implements json.Marshaler on its own (e.g. time.Time).
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return fmt.Errorf("ds: failed to marshal JSON signal: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+	}
+}
+
+func (e *Encoder) encodeStruct(w io.Writer, v reflect.Value) error {
+	t := v.Type()
+	if _, err := io.WriteString(w, "{"); err != nil {
+		return err
+	}
+	first := true
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" { // unexported
+			continue
+		}
+		name, omitempty, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+		fv := v.Field(i)
+		if omitempty && isEmptyValue(fv) {
+			continue
+		}
+		if !first {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+		key, _ := json.Marshal(name)
+		if _, err := w.Write(key); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, ":"); err != nil {
+			return err
+		}
+		if err := e.encodeValue(w, fv); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "}")
+	return err
+}
+
+func (e *Encoder) encodeMap(w io.Writer, v reflect.Value) error {
+	if v.IsNil() {
+		_, err := io.WriteString(w, "null")
+		return err
+	}
+	keys := v.MapKeys()
+	sortedKeys := make([]string, len(keys))
+	byKey := make(map[string]reflect.Value, len(keys))
+	for i, k := range keys {
+		ks := fmt.Sprint(k.Interface())
+		sortedKeys[i] = ks
+		byKey[ks] = v.MapIndex(k)
+	}
+	sort.Strings(sortedKeys)
+
+	if _, err := io.WriteString(w, "{"); err != nil {
+		return err
+	}
+	for i, k := range sortedKeys {
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		key, _ := json.Marshal(k)
+		if _, err := w.Write(key); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, ":"); err != nil {
+			return err
+		}
+		if err := e.encodeValue(w, byKey[k]); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "}")
+	return err
+}
+
+// jsonFieldName resolves a struct field's JSON key the same way
+// encoding/json does: a `json:"name,omitempty"` tag wins, "-" skips the
+// field entirely, and an untagged field falls back to its Go name.
+func jsonFieldName(field reflect.StructField) (name string, omitempty, skip bool) {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		return field.Name, false, false
+	}
+	parts := strings.Split(tag, ",")
+	if parts[0] == "-" && len(parts) == 1 {
+		return "", false, true
+	}
+	name = parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return name, omitempty, false
+}
+
+// isEmptyValue reports whether v is its type's zero value, for omitempty.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+// DefaultEncoder is the Encoder ds.JSON/ds.JSONSafe/ds.JSONTo use. It
+// renders time.Duration as an ISO-8601 duration string, time.Time as
+// RFC3339Nano (matching time.Time's own MarshalJSON), and
+// big.Int/big.Rat/big.Float as JSON strings so they survive the round trip
+// through JS's float64 Number type.
+var DefaultEncoder = newDefaultEncoder()
+
+func newDefaultEncoder() *Encoder {
+	e := NewEncoder()
+	e.RegisterType(reflect.TypeOf(time.Duration(0)), func(v any) ([]byte, error) {
+		return json.Marshal(formatISO8601Duration(v.(time.Duration)))
+	})
+	e.RegisterType(reflect.TypeOf(time.Time{}), func(v any) ([]byte, error) {
+		return json.Marshal(v.(time.Time).Format(time.RFC3339Nano))
+	})
+	e.RegisterType(reflect.TypeOf(&big.Int{}), func(v any) ([]byte, error) {
+		return json.Marshal(v.(*big.Int).String())
+	})
+	e.RegisterType(reflect.TypeOf(&big.Rat{}), func(v any) ([]byte, error) {
+		return json.Marshal(v.(*big.Rat).RatString())
+	})
+	e.RegisterType(reflect.TypeOf(&big.Float{}), func(v any) ([]byte, error) {
+		return json.Marshal(v.(*big.Float).Text('g', -1))
+	})
+	return e
+}
+
+// formatISO8601Duration renders d as an ISO-8601 duration ("PT1H2M3.5S"),
+// the format JS duration libraries (Luxon, date-fns) parse directly,
+// instead of the bare nanosecond count encoding/json would produce.
+func formatISO8601Duration(d time.Duration) string {
+	neg := d < 0
+	if neg {
+		d = -d
+	}
+	h := d / time.Hour
+	d -= h * time.Hour
+	m := d / time.Minute
+	d -= m * time.Minute
+	s := float64(d) / float64(time.Second)
+
+	var b strings.Builder
+	if neg {
+		b.WriteByte('-')
+	}
+	b.WriteString("PT")
+	if h > 0 {
+		b.WriteString(strconv.FormatInt(int64(h), 10))
+		b.WriteByte('H')
+	}
+	if m > 0 {
+		b.WriteString(strconv.FormatInt(int64(m), 10))
+		b.WriteByte('M')
+	}
+	if s != 0 || (h == 0 && m == 0) {
+		b.WriteString(strconv.FormatFloat(s, 'f', -1, 64))
+		b.WriteByte('S')
+	}
+	return b.String()
+}
@@ -0,0 +1,113 @@
+package ds_test
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ds "github.com/Yacobolo/datastar-templ"
+)
+
+func TestDefaultEncoderBuiltinHooks(t *testing.T) {
+	t.Run("time.Duration as ISO-8601", func(t *testing.T) {
+		data, err := ds.DefaultEncoder.Marshal(90 * time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, `"PT1H30M"`, string(data))
+	})
+
+	t.Run("negative duration", func(t *testing.T) {
+		data, err := ds.DefaultEncoder.Marshal(-500 * time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, `"-PT0.5S"`, string(data))
+	})
+
+	t.Run("time.Time as RFC3339Nano", func(t *testing.T) {
+		ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		data, err := ds.DefaultEncoder.Marshal(ts)
+		require.NoError(t, err)
+		assert.Equal(t, `"`+ts.Format(time.RFC3339Nano)+`"`, string(data))
+	})
+
+	t.Run("big.Int as a string", func(t *testing.T) {
+		n := new(big.Int).SetInt64(9007199254740993) // 2^53 + 1
+		data, err := ds.DefaultEncoder.Marshal(n)
+		require.NoError(t, err)
+		assert.Equal(t, `"9007199254740993"`, string(data))
+	})
+
+	t.Run("big.Rat as a string", func(t *testing.T) {
+		r := big.NewRat(1, 3)
+		data, err := ds.DefaultEncoder.Marshal(r)
+		require.NoError(t, err)
+		assert.Equal(t, `"1/3"`, string(data))
+	})
+
+	t.Run("hooks apply to nested struct fields", func(t *testing.T) {
+		type Job struct {
+			Name    string        `json:"name"`
+			Elapsed time.Duration `json:"elapsed"`
+		}
+		data, err := ds.DefaultEncoder.Marshal(Job{Name: "build", Elapsed: 2 * time.Second})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name": "build", "elapsed": "PT2S"}`, string(data))
+	})
+
+	t.Run("hooks apply inside slices", func(t *testing.T) {
+		data, err := ds.DefaultEncoder.Marshal([]time.Duration{time.Second, 2 * time.Second})
+		require.NoError(t, err)
+		assert.JSONEq(t, `["PT1S", "PT2S"]`, string(data))
+	})
+}
+
+type money int64
+
+func TestEncoderRegisterType(t *testing.T) {
+	enc := ds.NewEncoder()
+	enc.RegisterType(reflect.TypeOf(money(0)), func(v any) ([]byte, error) {
+		return []byte(`"$10.99"`), nil
+	})
+
+	data, err := enc.Marshal(money(1099))
+	require.NoError(t, err)
+	assert.Equal(t, `"$10.99"`, string(data))
+}
+
+func TestEncoderOmitempty(t *testing.T) {
+	type Payload struct {
+		Name  string `json:"name"`
+		Notes string `json:"notes,omitempty"`
+		Count int    `json:"count,omitempty"`
+	}
+	data, err := ds.DefaultEncoder.Marshal(Payload{Name: "x"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name": "x"}`, string(data))
+}
+
+func TestJSONTo(t *testing.T) {
+	var b strings.Builder
+	err := ds.JSONTo(&b, "elapsed", 2*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, `"PT2S"`, b.String())
+
+	err = ds.JSONTo(&b, "bad", make(chan int))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"bad"`)
+}
+
+func TestSignalsWith(t *testing.T) {
+	t.Run("re-marshals JSON signals with the custom encoder", func(t *testing.T) {
+		enc := ds.NewEncoder()
+		enc.RegisterType(reflect.TypeOf(money(0)), func(v any) ([]byte, error) {
+			return []byte(`"$10.99"`), nil
+		})
+
+		attrs := ds.SignalsWith(enc, ds.JSON("price", money(1099)), ds.Int("qty", 2))
+		assert.Contains(t, attrs["data-signals"], `price: "$10.99"`)
+		assert.Contains(t, attrs["data-signals"], "qty: 2")
+	})
+}
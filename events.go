@@ -310,6 +310,110 @@ func OnError(expr string, modifiers ...Modifier) templ.Attributes {
 	return val(on(eventError, modifiers), expr)
 }
 
+// OnAbort handles the "abort" event, fired when an <audio>/<video> load is aborted.
+func OnAbort(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventAbort, modifiers), expr)
+}
+
+// OnCanPlay handles the "canplay" event.
+func OnCanPlay(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventCanPlay, modifiers), expr)
+}
+
+// OnCanPlayThrough handles the "canplaythrough" event.
+func OnCanPlayThrough(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventCanPlayThrough, modifiers), expr)
+}
+
+// OnDurationChange handles the "durationchange" event.
+func OnDurationChange(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventDurationChange, modifiers), expr)
+}
+
+// OnEnded handles the "ended" event, fired when playback reaches the end.
+func OnEnded(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventEnded, modifiers), expr)
+}
+
+// OnLoadedData handles the "loadeddata" event.
+func OnLoadedData(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventLoadedData, modifiers), expr)
+}
+
+// OnLoadedMetadata handles the "loadedmetadata" event.
+func OnLoadedMetadata(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventLoadedMetadata, modifiers), expr)
+}
+
+// OnLoadStart handles the "loadstart" event.
+func OnLoadStart(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventLoadStart, modifiers), expr)
+}
+
+// OnPause handles the "pause" event.
+func OnPause(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventPause, modifiers), expr)
+}
+
+// OnPlay handles the "play" event.
+func OnPlay(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventPlay, modifiers), expr)
+}
+
+// OnPlaying handles the "playing" event, fired when playback resumes after buffering.
+func OnPlaying(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventPlaying, modifiers), expr)
+}
+
+// OnProgress handles the "progress" event, fired periodically while the
+// browser fetches media data.
+func OnProgress(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventProgress, modifiers), expr)
+}
+
+// OnRateChange handles the "ratechange" event.
+func OnRateChange(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventRateChange, modifiers), expr)
+}
+
+// OnSeeked handles the "seeked" event.
+func OnSeeked(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventSeeked, modifiers), expr)
+}
+
+// OnSeeking handles the "seeking" event.
+func OnSeeking(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventSeeking, modifiers), expr)
+}
+
+// OnStalled handles the "stalled" event, fired when the browser tries to
+// fetch media data but data is unexpectedly unavailable.
+func OnStalled(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventStalled, modifiers), expr)
+}
+
+// OnSuspend handles the "suspend" event, fired when media data loading is
+// intentionally suspended.
+func OnSuspend(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventSuspend, modifiers), expr)
+}
+
+// OnTimeUpdate handles the "timeupdate" event, fired as playback position changes.
+func OnTimeUpdate(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventTimeUpdate, modifiers), expr)
+}
+
+// OnVolumeChange handles the "volumechange" event.
+func OnVolumeChange(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventVolumeChange, modifiers), expr)
+}
+
+// OnWaiting handles the "waiting" event, fired when playback stops due to
+// a temporary lack of data.
+func OnWaiting(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventWaiting, modifiers), expr)
+}
+
 // ---------------------------------------------------------------------------
 // Clipboard events
 // ---------------------------------------------------------------------------
@@ -329,6 +433,46 @@ func OnPaste(expr string, modifiers ...Modifier) templ.Attributes {
 	return val(on(eventPaste, modifiers), expr)
 }
 
+// ---------------------------------------------------------------------------
+// Composition / input editor events
+// ---------------------------------------------------------------------------
+
+// OnCompositionStart handles the "compositionstart" event, fired when an IME
+// or similar input method begins a composition session.
+func OnCompositionStart(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventCompositionStart, modifiers), expr)
+}
+
+// OnCompositionUpdate handles the "compositionupdate" event, fired as the
+// composed text changes mid-session.
+func OnCompositionUpdate(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventCompositionUpdate, modifiers), expr)
+}
+
+// OnCompositionEnd handles the "compositionend" event, fired when a
+// composition session is committed or cancelled.
+func OnCompositionEnd(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventCompositionEnd, modifiers), expr)
+}
+
+// OnBeforeInput handles the "beforeinput" event, fired before the DOM is
+// mutated by an editing action, allowing inspection of the pending change.
+func OnBeforeInput(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventBeforeInput, modifiers), expr)
+}
+
+// OnSelectionChange handles the "selectionchange" event. The event only ever
+// fires on document, so the __window modifier is always applied.
+func OnSelectionChange(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventSelectionChange, append([]Modifier{ModWindow}, modifiers...)), expr)
+}
+
+// OnAuxClick handles the "auxclick" event, fired for non-primary pointer
+// buttons such as the middle mouse button.
+func OnAuxClick(expr string, modifiers ...Modifier) templ.Attributes {
+	return val(on(eventAuxClick, modifiers), expr)
+}
+
 // ---------------------------------------------------------------------------
 // Custom event escape hatch
 // ---------------------------------------------------------------------------
@@ -0,0 +1,84 @@
+package ds
+
+import "fmt"
+
+// ---------------------------------------------------------------------------
+// ExprString / Schema
+//
+// Expr (handles.go) already builds a typed JS expression AST and tracks the
+// signal names it references via Uses(). Schema closes the remaining gap:
+// asserting that every signal an Expr references was actually declared,
+// catching a typo'd or renamed signal at construction time instead of as a
+// silent no-op in the browser.
+// ---------------------------------------------------------------------------
+
+// ExprString renders e to its JS expression string, the Expression
+// counterpart to calling String()/RenderJS() directly — useful when passing
+// a typed expression into a string-based helper that has no XxxExpr variant.
+func ExprString(e Expression) string { return e.RenderJS() }
+
+// SignalKind categorizes a declared signal's value type. Schema records it,
+// but neither Check nor Validate reads it, and neither
+// attempts to verify an expression actually *uses* a signal in a way
+// compatible with its kind — that would require parsing the surrounding JS,
+// not just scanning for "$name". A schema violation here always means
+// "referenced but never declared".
+type SignalKind int
+
+// Signal value kinds, matching the Int/String/Bool/Float/JSON constructors.
+const (
+	KindAny SignalKind = iota
+	KindInt
+	KindString
+	KindBool
+	KindFloat
+	KindJSON
+)
+
+// Schema is a declared set of signal names (with an optional SignalKind per
+// name), checked against the signals an Expr's Uses() or a rendered
+// attribute string reference.
+type Schema struct {
+	kinds map[string]SignalKind
+}
+
+// NewSchema builds a Schema from the signal handles a component declares,
+// for use with Check/MustCheck against an Expr. Handles don't carry a
+// SignalKind, so every entry is recorded as KindAny.
+//
+//	price, priceSig := ds.NewFloat("price", 0)
+//	qty, qtySig := ds.NewInt("qty", 1)
+//	schema := ds.NewSchema(price, qty)
+func NewSchema(handles ...signalRef) Schema {
+	kinds := make(map[string]SignalKind, len(handles))
+	for _, h := range handles {
+		kinds[h.signalName()] = KindAny
+	}
+	return Schema{kinds: kinds}
+}
+
+// Check reports the first signal name e references (via Expr.Uses()) that
+// isn't in s. ok is true if e isn't an Expr, or every referenced signal is
+// declared.
+func (s Schema) Check(e Expression) (undeclared string, ok bool) {
+	expr, isExpr := e.(Expr)
+	if !isExpr {
+		return "", true
+	}
+	for _, name := range expr.Uses() {
+		if _, declared := s.kinds[name]; !declared {
+			return name, false
+		}
+	}
+	return "", true
+}
+
+// MustCheck panics if e references a signal not declared in s.
+//
+//	schema.MustCheck(ds.ExprOf(price).Mul(ds.ExprOf(qty))) // ok
+//	schema.MustCheck(ds.Sig("discount"))                   // panics: undeclared
+func (s Schema) MustCheck(e Expression) {
+	if name, ok := s.Check(e); !ok {
+		panic(fmt.Sprintf("ds: expression references undeclared signal %q", name))
+	}
+}
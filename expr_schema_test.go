@@ -0,0 +1,48 @@
+package ds_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ds "github.com/Yacobolo/datastar-templ"
+)
+
+func TestExprString(t *testing.T) {
+	count, _ := ds.NewInt("count", 0)
+	e := ds.ExprOf(count).Add(ds.ExprLit(1))
+	assert.Equal(t, "$count + 1", ds.ExprString(e))
+}
+
+func TestSchema(t *testing.T) {
+	price, _ := ds.NewFloat("price", 0)
+	qty, _ := ds.NewInt("qty", 1)
+	schema := ds.NewSchema(price, qty)
+
+	t.Run("passes when every referenced signal is declared", func(t *testing.T) {
+		total := ds.ExprOf(price).Mul(ds.ExprOf(qty))
+		name, ok := schema.Check(total)
+		assert.True(t, ok)
+		assert.Empty(t, name)
+	})
+
+	t.Run("reports the first undeclared signal", func(t *testing.T) {
+		name, ok := schema.Check(ds.Sig("discount"))
+		assert.False(t, ok)
+		assert.Equal(t, "discount", name)
+	})
+
+	t.Run("non-Expr expressions always pass", func(t *testing.T) {
+		name, ok := schema.Check(ds.ExprRaw("window.location.reload()"))
+		assert.True(t, ok)
+		assert.Empty(t, name)
+	})
+
+	t.Run("MustCheck panics on an undeclared signal", func(t *testing.T) {
+		assert.Panics(t, func() { schema.MustCheck(ds.Sig("discount")) })
+	})
+
+	t.Run("MustCheck passes silently when declared", func(t *testing.T) {
+		assert.NotPanics(t, func() { schema.MustCheck(ds.ExprOf(price)) })
+	})
+}
@@ -0,0 +1,94 @@
+package ds
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+// ---------------------------------------------------------------------------
+// FilterPattern
+//
+// Filter.Include/Exclude used to be plain strings, so callers had to hand-write
+// the leading/trailing "/.../" delimiters Datastar expects and remember to
+// escape any literal slash themselves — get either wrong and the filter
+// silently becomes invalid regex syntax on the client. FilterPattern moves
+// that to construction time: Regex/RegexRaw validate and wrap the pattern,
+// and the combinators below cover the common cases without touching a regex
+// at all. A plain string literal is still accepted directly on Filter for
+// back-compat, since FilterPattern's underlying type is string.
+// ---------------------------------------------------------------------------
+
+// FilterPattern is a Datastar filter regex, already wrapped in "/.../"
+// delimiters. Build one with Regex, RegexRaw, or one of the combinators
+// (SignalPrefix, AnyOf, NotUnderscored) instead of hand-writing the
+// delimiters and escaping.
+type FilterPattern string
+
+// Regex validates pattern with regexp/syntax and wraps it in "/.../"
+// delimiters, escaping any literal slash so it doesn't terminate the
+// pattern early.
+//
+// Panics if pattern is not valid regex syntax.
+func Regex(pattern string) FilterPattern {
+	if _, err := syntax.Parse(pattern, syntax.Perl); err != nil {
+		panic(fmt.Sprintf("ds: invalid filter regex %q: %v", pattern, err))
+	}
+	return FilterPattern("/" + strings.ReplaceAll(pattern, "/", `\/`) + "/")
+}
+
+// RegexRaw wraps pattern in "/.../" without validating it, for dialect
+// features (e.g. lookbehind) Go's regexp/syntax doesn't parse but the
+// browser's regex engine accepts.
+func RegexRaw(pattern string) FilterPattern {
+	return FilterPattern("/" + pattern + "/")
+}
+
+// SignalPrefix returns a pattern matching any signal nested under prefix.
+//
+//	ds.SignalPrefix("user") // -> /^user\./
+func SignalPrefix(prefix string) FilterPattern {
+	return Regex("^" + regexp.QuoteMeta(prefix) + `\.`)
+}
+
+// AnyOf returns a pattern matching exactly one of names.
+//
+//	ds.AnyOf("user", "admin") // -> /^(user|admin)$/
+func AnyOf(names ...string) FilterPattern {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = regexp.QuoteMeta(name)
+	}
+	return Regex("^(" + strings.Join(quoted, "|") + ")$")
+}
+
+// NotUnderscored returns a pattern matching signal names that don't start
+// with an underscore, the convention Datastar itself uses for private signals.
+//
+//	ds.NotUnderscored() // -> /^[^_]/
+func NotUnderscored() FilterPattern {
+	return Regex(`^[^_]`)
+}
+
+// unwrap strips the "/.../" delimiters and undoes the slash-escaping Regex
+// applies, recovering the pattern regexp.Compile expects.
+func (p FilterPattern) unwrap() string {
+	s := strings.TrimSuffix(strings.TrimPrefix(string(p), "/"), "/")
+	return strings.ReplaceAll(s, `\/`, "/")
+}
+
+// MatchesSignal reports whether name matches this pattern. An empty pattern
+// matches every name. Lets server-side code (e.g. sse.Writer.PatchSignalsIfMatch)
+// pre-filter signals before encoding them, mirroring what data-json-signals
+// does in the browser.
+func (p FilterPattern) MatchesSignal(name string) bool {
+	if p == "" {
+		return true
+	}
+	re, err := regexp.Compile(p.unwrap())
+	if err != nil {
+		return false
+	}
+	return re.MatchString(name)
+}
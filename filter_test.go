@@ -0,0 +1,81 @@
+package ds_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ds "github.com/Yacobolo/datastar-templ"
+)
+
+func TestRegex(t *testing.T) {
+	t.Run("wraps in delimiters", func(t *testing.T) {
+		assert.Equal(t, ds.FilterPattern("/^user/"), ds.Regex("^user"))
+	})
+
+	t.Run("escapes literal slashes", func(t *testing.T) {
+		assert.Equal(t, ds.FilterPattern(`/a\/b/`), ds.Regex("a/b"))
+	})
+
+	t.Run("panics on invalid regex syntax", func(t *testing.T) {
+		assert.Panics(t, func() { ds.Regex("(unclosed") })
+	})
+}
+
+func TestRegexRaw(t *testing.T) {
+	t.Run("wraps without validating", func(t *testing.T) {
+		assert.Equal(t, ds.FilterPattern("/(?<=foo)bar/"), ds.RegexRaw("(?<=foo)bar"))
+	})
+
+	t.Run("does not panic on patterns Go can't parse", func(t *testing.T) {
+		assert.NotPanics(t, func() { ds.RegexRaw("(?<=foo)bar") })
+	})
+}
+
+func TestSignalPrefix(t *testing.T) {
+	assert.Equal(t, ds.FilterPattern(`/^user\./`), ds.SignalPrefix("user"))
+}
+
+func TestAnyOf(t *testing.T) {
+	assert.Equal(t, ds.FilterPattern("/^(user|admin)$/"), ds.AnyOf("user", "admin"))
+}
+
+func TestNotUnderscored(t *testing.T) {
+	assert.Equal(t, ds.FilterPattern("/^[^_]/"), ds.NotUnderscored())
+}
+
+func TestFilterPatternMatchesSignal(t *testing.T) {
+	t.Run("empty pattern matches everything", func(t *testing.T) {
+		var p ds.FilterPattern
+		assert.True(t, p.MatchesSignal("anything"))
+	})
+
+	t.Run("SignalPrefix matches nested signals", func(t *testing.T) {
+		p := ds.SignalPrefix("user")
+		assert.True(t, p.MatchesSignal("user.name"))
+		assert.False(t, p.MatchesSignal("admin.name"))
+	})
+
+	t.Run("AnyOf matches exact names", func(t *testing.T) {
+		p := ds.AnyOf("user", "admin")
+		assert.True(t, p.MatchesSignal("admin"))
+		assert.False(t, p.MatchesSignal("administrator"))
+	})
+
+	t.Run("NotUnderscored rejects private signals", func(t *testing.T) {
+		p := ds.NotUnderscored()
+		assert.True(t, p.MatchesSignal("count"))
+		assert.False(t, p.MatchesSignal("_internal"))
+	})
+
+	t.Run("plain string literal still works", func(t *testing.T) {
+		f := ds.Filter{Include: "/^foo/"}
+		assert.True(t, f.Include.MatchesSignal("foobar"))
+		assert.False(t, f.Include.MatchesSignal("bar"))
+	})
+}
+
+func TestFilterAcceptsPatternConstructors(t *testing.T) {
+	attrs := ds.JSONSignals(ds.Filter{Include: ds.SignalPrefix("user"), Exclude: ds.AnyOf("userPassword")})
+	assert.Equal(t, `{include: /^user\./, exclude: /^(userPassword)$/}`, attrs["data-json-signals"])
+}
@@ -0,0 +1,366 @@
+// Package gen generates typed signal-accessor code from a Go struct
+// definition, so templ authors stop spelling signal names as bare strings
+// like "$count" or "$count++".
+//
+// A struct such as
+//
+//	type State struct {
+//		Count   int    `ds:"count"`
+//		Message string `ds:"message"`
+//	}
+//
+// produces a sibling package exposing state.Count.Ref() ("$count"),
+// state.Count.Inc() ("$count++"), and state.Initial() (a ds.Signals(...)
+// call seeded with the struct's zero/declared values). The generated code
+// is built entirely on top of the existing ds.Int/String/Bool/Float/JSON
+// helpers in the parent package; nothing about the runtime changes.
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// Kind is the Datastar signal kind a struct field maps to.
+type Kind string
+
+const (
+	KindInt    Kind = "int"
+	KindString Kind = "string"
+	KindBool   Kind = "bool"
+	KindFloat  Kind = "float"
+	KindJSON   Kind = "json"
+)
+
+// Field describes one generated signal accessor.
+type Field struct {
+	// GoName is the struct field's Go identifier, e.g. "Count".
+	GoName string
+	// Signal is the Datastar signal name, e.g. "count".
+	Signal string
+	// Kind is the signal's value kind, which selects the ds.* constructor
+	// and the generated accessor methods.
+	Kind Kind
+}
+
+// Spec is a parsed struct ready for code generation.
+type Spec struct {
+	// TypeName is the Go struct's name, e.g. "State".
+	TypeName string
+	// SourcePackage is the import path of the package declaring TypeName.
+	SourcePackage string
+	Fields        []Field
+}
+
+// ParseStruct reads typeName out of filename and builds a Spec from its
+// exported fields. Field tags use the form `ds:"name,type=kind,omitempty"`;
+// the name defaults to the lower-camel-case field name, and the kind is
+// inferred from the Go field type when not given explicitly.
+//
+//	Count int                        -> Field{GoName: "Count", Signal: "count", Kind: KindInt}
+//	Message string `ds:"msg"`        -> Field{GoName: "Message", Signal: "msg", Kind: KindString}
+//	Items []Todo `ds:",type=json"`   -> Field{GoName: "Items", Signal: "items", Kind: KindJSON}
+func ParseStruct(filename, typeName string) (*Spec, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("gen: parsing %s: %w", filename, err)
+	}
+
+	var structType *ast.StructType
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if !ok || ts.Name.Name != typeName {
+				continue
+			}
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				return nil, fmt.Errorf("gen: %s is not a struct", typeName)
+			}
+			structType = st
+		}
+	}
+	if structType == nil {
+		return nil, fmt.Errorf("gen: type %s not found in %s", typeName, filename)
+	}
+
+	spec := &Spec{TypeName: typeName, SourcePackage: file.Name.Name}
+	for _, f := range structType.Fields.List {
+		if len(f.Names) == 0 {
+			continue // skip embedded fields
+		}
+		for _, name := range f.Names {
+			if !name.IsExported() {
+				continue
+			}
+			field, err := fieldFromAST(name.Name, f)
+			if err != nil {
+				return nil, err
+			}
+			spec.Fields = append(spec.Fields, field)
+		}
+	}
+	return spec, nil
+}
+
+func fieldFromAST(goName string, f *ast.Field) (Field, error) {
+	signal := lowerCamel(goName)
+	var kindOverride string
+	if f.Tag != nil {
+		tag := reflect.StructTag(strings.Trim(f.Tag.Value, "`")).Get("ds")
+		parts := strings.Split(tag, ",")
+		if parts[0] != "" {
+			signal = parts[0]
+		}
+		for _, opt := range parts[1:] {
+			if strings.HasPrefix(opt, "type=") {
+				kindOverride = strings.TrimPrefix(opt, "type=")
+			}
+		}
+	}
+
+	kind := Kind(kindOverride)
+	if kind == "" {
+		var err error
+		kind, err = inferKind(f.Type)
+		if err != nil {
+			return Field{}, fmt.Errorf("gen: field %s: %w", goName, err)
+		}
+	}
+	return Field{GoName: goName, Signal: signal, Kind: kind}, nil
+}
+
+func inferKind(expr ast.Expr) (Kind, error) {
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return KindJSON, nil // slices, maps, structs, pointers -> JSON
+	}
+	switch ident.Name {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return KindInt, nil
+	case "string":
+		return KindString, nil
+	case "bool":
+		return KindBool, nil
+	case "float32", "float64":
+		return KindFloat, nil
+	default:
+		return KindJSON, nil
+	}
+}
+
+func lowerCamel(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+// Generate renders the accessor package source for the spec. pkgName is the
+// package name of the generated file (typically "<source>signals").
+func (s *Spec) Generate(pkgName string) ([]byte, error) {
+	tmpl := template.Must(template.New("gen").Funcs(template.FuncMap{
+		"ctor":   ctorFunc,
+		"goType": goType,
+	}).Parse(tmplSource))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct {
+		PkgName string
+		Spec    *Spec
+	}{pkgName, s}); err != nil {
+		return nil, fmt.Errorf("gen: executing template: %w", err)
+	}
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("gen: formatting generated source: %w", err)
+	}
+	return out, nil
+}
+
+// ctorFunc returns the ds.* constructor name for a Kind (Int, String, ...).
+func ctorFunc(k Kind) string {
+	switch k {
+	case KindInt:
+		return "Int"
+	case KindString:
+		return "String"
+	case KindBool:
+		return "Bool"
+	case KindFloat:
+		return "Float"
+	default:
+		return "JSON"
+	}
+}
+
+const tmplSource = `// Code generated by datastar-templ-gen. DO NOT EDIT.
+
+package {{.PkgName}}
+
+import (
+	"github.com/a-h/templ"
+	ds "github.com/Yacobolo/datastar-templ"
+)
+
+// {{.Spec.TypeName}}Signals exposes refactor-safe accessors for the
+// {{.Spec.TypeName}} signal bag declared in this package.
+type {{.Spec.TypeName}}Signals struct {
+{{- range .Spec.Fields}}
+	{{.GoName}} {{.GoName}}Signal
+{{- end}}
+}
+
+// {{.Spec.TypeName}} is the generated accessor set. Use its fields directly,
+// e.g. {{.Spec.TypeName}}.Count.Ref().
+var {{.Spec.TypeName}} = {{.Spec.TypeName}}Signals{
+{{- range .Spec.Fields}}
+	{{.GoName}}: {{.GoName}}Signal{name: "{{.Signal}}"},
+{{- end}}
+}
+
+// Initial builds the ds.Signals(...) call that seeds every generated
+// signal with the given struct's current values.
+func ({{.Spec.TypeName}}Signals) Initial(v {{.Spec.TypeName}}) templ.Attributes {
+	return ds.Signals(
+{{- range .Spec.Fields}}
+		{{$.Spec.TypeName}}.{{.GoName}}.Signal(v.{{.GoName}}),
+{{- end}}
+	)
+}
+
+{{range .Spec.Fields}}
+// {{.GoName}}Signal is a typed handle for the "{{.Signal}}" signal.
+type {{.GoName}}Signal struct{ name string }
+
+// Ref returns the "$" reference expression for this signal.
+func (s {{.GoName}}Signal) Ref() string { return "$" + s.name }
+{{if eq .Kind "int" -}}
+// Inc returns an expression that increments this signal.
+func (s {{.GoName}}Signal) Inc() string { return s.Ref() + "++" }
+
+// Dec returns an expression that decrements this signal.
+func (s {{.GoName}}Signal) Dec() string { return s.Ref() + "--" }
+{{end -}}
+// Set returns an assignment expression for this signal.
+func (s {{.GoName}}Signal) Set(expr string) string { return s.Ref() + " = " + expr }
+
+// Signal builds the ds.Signal initializer for this field.
+func (s {{.GoName}}Signal) Signal(value {{goType .Kind}}) ds.Signal { return ds.{{ctor .Kind}}(s.name, value) }
+{{end}}
+`
+
+// goType returns the Go parameter type accepted by a Kind's Signal method.
+func goType(k Kind) string {
+	switch k {
+	case KindInt:
+		return "int"
+	case KindString:
+		return "string"
+	case KindBool:
+		return "bool"
+	case KindFloat:
+		return "float64"
+	default:
+		return "any"
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Unknown-signal checker
+//
+// A go vet-style pass that flags ds.* calls whose string-literal arguments
+// reference a "$name" not present in a generated Spec, so a renamed signal
+// field shows up as a build-time failure instead of a silent no-op.
+// ---------------------------------------------------------------------------
+
+// Violation describes a "$name" reference to an undeclared signal.
+type Violation struct {
+	Pos    token.Position
+	Signal string
+}
+
+// dsSignalExpr matches "$identifier" (and dotted paths like "$user.name")
+// anywhere in the literal, including inside nested JS quotes; it's
+// intentionally simple and scans only the literal's own contents, not
+// arbitrary JS.
+var dsSignalExpr = regexp.MustCompile(`\$[A-Za-z_][A-Za-z0-9_]*(?:\.[A-Za-z_][A-Za-z0-9_]*)*`)
+
+// CheckUnknownSignals parses filename and reports every "$name" found inside
+// a string-literal argument to a "ds."-qualified call whose base name isn't
+// in declared. Pass the Signal names from a gen.Spec (or any other set of
+// signals you consider valid) as declared.
+func CheckUnknownSignals(filename string, declared map[string]bool) ([]Violation, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("gen: parsing %s: %w", filename, err)
+	}
+
+	var violations []Violation
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		pkgIdent, ok := sel.X.(*ast.Ident)
+		if !ok || pkgIdent.Name != "ds" {
+			return true
+		}
+		for _, arg := range call.Args {
+			lit, ok := arg.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				continue
+			}
+			unquoted, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				continue
+			}
+			for _, match := range dsSignalExpr.FindAllString(unquoted, -1) {
+				name := match[1:] // strip leading "$"
+				if root, _, found := cutFirst(name, '.'); found {
+					name = root
+				}
+				if !declared[name] {
+					violations = append(violations, Violation{
+						Pos:    fset.Position(lit.Pos()),
+						Signal: name,
+					})
+				}
+			}
+		}
+		return true
+	})
+	return violations, nil
+}
+
+func cutFirst(s string, sep byte) (before string, after string, found bool) {
+	for i := 0; i < len(s); i++ {
+		if s[i] == sep {
+			return s[:i], s[i+1:], true
+		}
+	}
+	return s, "", false
+}
@@ -0,0 +1,83 @@
+package gen_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Yacobolo/datastar-templ/gen"
+)
+
+const fixtureSource = `package state
+
+type State struct {
+	Count   int
+	Message string ` + "`ds:\"msg\"`" + `
+	Items   []string
+}
+`
+
+func writeFixture(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.go")
+	require.NoError(t, os.WriteFile(path, []byte(fixtureSource), 0o644))
+	return path
+}
+
+func TestParseStruct(t *testing.T) {
+	path := writeFixture(t)
+
+	spec, err := gen.ParseStruct(path, "State")
+	require.NoError(t, err)
+	require.Len(t, spec.Fields, 3)
+
+	assert.Equal(t, gen.Field{GoName: "Count", Signal: "count", Kind: gen.KindInt}, spec.Fields[0])
+	assert.Equal(t, gen.Field{GoName: "Message", Signal: "msg", Kind: gen.KindString}, spec.Fields[1])
+	assert.Equal(t, gen.Field{GoName: "Items", Signal: "items", Kind: gen.KindJSON}, spec.Fields[2])
+}
+
+func TestParseStruct_unknownType(t *testing.T) {
+	path := writeFixture(t)
+	_, err := gen.ParseStruct(path, "Missing")
+	assert.Error(t, err)
+}
+
+func TestGenerate(t *testing.T) {
+	path := writeFixture(t)
+	spec, err := gen.ParseStruct(path, "State")
+	require.NoError(t, err)
+
+	src, err := spec.Generate("state")
+	require.NoError(t, err)
+
+	out := string(src)
+	assert.Contains(t, out, "func (s CountSignal) Ref() string")
+	assert.Contains(t, out, `return "$" + s.name`)
+	assert.Contains(t, out, "func (s CountSignal) Inc() string")
+	assert.Contains(t, out, "func (s MessageSignal) Set(expr string) string")
+	assert.Contains(t, out, "ds.JSON(s.name, value)")
+}
+
+func TestCheckUnknownSignals(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "page.go")
+	src := `package page
+
+import ds "github.com/Yacobolo/datastar-templ"
+
+func view() {
+	_ = ds.Show("$count > 0")
+	_ = ds.Text("$quantiy")
+}
+`
+	require.NoError(t, os.WriteFile(path, []byte(src), 0o644))
+
+	violations, err := gen.CheckUnknownSignals(path, map[string]bool{"count": true})
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "quantiy", violations[0].Signal)
+}
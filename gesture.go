@@ -0,0 +1,295 @@
+package ds
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/a-h/templ"
+)
+
+// ---------------------------------------------------------------------------
+// Gestures
+//
+// Framer Motion's pointer pipeline collapses mouse/touch/pen into a single
+// PointerEvent stream and layers press/pan/swipe semantics on top of it
+// instead of making callers juggle five listeners per gesture. The On*
+// helpers in events.go give raw access to that stream but leave the
+// composition (pointerdown + pointermove + pointerup + pointercancel, plus
+// tracking where the gesture started and how far it's moved) to the caller.
+// The gesture helpers below do that composition once: each wires up the
+// relevant data-on:pointer* attributes and a private data-signals companion
+// that holds the shared bookkeeping (a down flag, start x/y, delta,
+// velocity), so expr only has to express what happens when the gesture
+// resolves.
+//
+// The companion signal is namespaced "_gestureN" in call order (see
+// NotUnderscored for the "_"-prefix private-signal convention); it exists to
+// let a gesture's own listeners talk to each other and isn't meant to be
+// referenced by name elsewhere, since that name isn't stable across renders
+// that call these helpers conditionally.
+// ---------------------------------------------------------------------------
+
+// gestureSeq numbers gesture signal namespaces in call order.
+var gestureSeq atomic.Uint64
+
+// gestureState allocates the next "_gestureN" namespace and its initial
+// data-signals value, shared by every gesture helper.
+func gestureState() (string, Signal) {
+	ns := fmt.Sprintf("_gesture%d", gestureSeq.Add(1))
+	return ns, Signal{key: ns, value: "{down: false, x: 0, y: 0, dx: 0, dy: 0, vx: 0, vy: 0, t: 0}"}
+}
+
+// gestureConfig holds the resolved options for a gesture helper call.
+type gestureConfig struct {
+	longPress      time.Duration
+	panAxis        string
+	swipeDir       string
+	swipeThreshold int
+	pressTolerance int
+}
+
+func defaultGestureConfig() gestureConfig {
+	return gestureConfig{
+		longPress:      500 * time.Millisecond,
+		swipeThreshold: 50,
+		pressTolerance: 10,
+	}
+}
+
+// GestureOpt configures a gesture helper (OnPress, OnLongPress, OnPan,
+// OnSwipe, OnHover).
+type GestureOpt func(*gestureConfig)
+
+// LongPressDuration sets how long OnLongPress waits after pointerdown before
+// firing, overriding the 500ms default. Renders into the datastar-native
+// __delay modifier rather than a signal, since the delay is a property of
+// the listener, not the gesture's tracked state.
+func LongPressDuration(d time.Duration) GestureOpt {
+	return func(c *gestureConfig) { c.longPress = d }
+}
+
+// PanAxis constrains OnPan to tracking a single axis ("x" or "y"); left
+// unset, OnPan tracks both.
+//
+// Panics if axis is not "x" or "y".
+func PanAxis(axis string) GestureOpt {
+	if axis != "x" && axis != "y" {
+		panic(fmt.Sprintf("ds: PanAxis: axis must be \"x\" or \"y\", got %q", axis))
+	}
+	return func(c *gestureConfig) { c.panAxis = axis }
+}
+
+// SwipeDirection constrains OnSwipe to one cardinal direction ("left",
+// "right", "up", "down"); left unset, OnSwipe fires for whichever axis
+// moved furthest.
+//
+// Panics if direction isn't one of the four cardinal directions.
+func SwipeDirection(direction string) GestureOpt {
+	switch direction {
+	case "left", "right", "up", "down":
+	default:
+		panic(fmt.Sprintf("ds: SwipeDirection: must be left, right, up, or down, got %q", direction))
+	}
+	return func(c *gestureConfig) { c.swipeDir = direction }
+}
+
+// SwipeThreshold sets the minimum distance in pixels OnSwipe requires before
+// firing, overriding the 50px default.
+//
+// Panics if px isn't positive.
+func SwipeThreshold(px int) GestureOpt {
+	if px <= 0 {
+		panic(fmt.Sprintf("ds: SwipeThreshold: must be positive, got %d", px))
+	}
+	return func(c *gestureConfig) { c.swipeThreshold = px }
+}
+
+// PressTolerance sets how far in pixels OnPress allows the pointer to drift
+// between down and up and still count as a press rather than a drag,
+// overriding the 10px default.
+//
+// Panics if px is negative.
+func PressTolerance(px int) GestureOpt {
+	if px < 0 {
+		panic(fmt.Sprintf("ds: PressTolerance: must not be negative, got %d", px))
+	}
+	return func(c *gestureConfig) { c.pressTolerance = px }
+}
+
+// gestureDown is the expression every gesture records on pointerdown: mark
+// pressed, capture the start position, reset delta, stamp the start time.
+func gestureDown(ns string) string {
+	return fmt.Sprintf("$%s.down = true; $%s.x = evt.clientX; $%s.y = evt.clientY; $%s.dx = 0; $%s.dy = 0; $%s.t = Date.now()",
+		ns, ns, ns, ns, ns, ns)
+}
+
+// gestureReset clears a gesture's pressed state, run on pointerup/pointercancel.
+func gestureReset(ns string) string {
+	return fmt.Sprintf("$%s.down = false", ns)
+}
+
+// gestureDelta is the expression that recomputes dx/dy (and the vx/vy
+// velocity they imply) from the current pointer position, zeroing one axis
+// when axis is "x" or "y" (PanAxis).
+func gestureDelta(ns, axis string) string {
+	dx, dy := fmt.Sprintf("evt.clientX - $%s.x", ns), fmt.Sprintf("evt.clientY - $%s.y", ns)
+	switch axis {
+	case "x":
+		dy = "0"
+	case "y":
+		dx = "0"
+	}
+	elapsed := fmt.Sprintf("Math.max(1, Date.now() - $%s.t)", ns)
+	return fmt.Sprintf("$%s.dx = %s; $%s.dy = %s; $%s.vx = $%s.dx / %s; $%s.vy = $%s.dy / %s",
+		ns, dx, ns, dy, ns, ns, elapsed, ns, ns, elapsed)
+}
+
+// OnPress fires expr when the pointer is pressed and released without
+// drifting more than PressTolerance pixels (default 10), the pointer-unified
+// equivalent of OnClick that also covers touch/pen without a synthesized
+// click event.
+//
+//	{ ds.OnPress("$count++")... }
+//	{ ds.OnPress("$count++", ds.PressTolerance(4))... }
+func OnPress(expr string, opts ...GestureOpt) templ.Attributes {
+	cfg := defaultGestureConfig()
+	for _, o := range opts {
+		o(&cfg)
+	}
+	ns, sig := gestureState()
+
+	onUp := strings.Join([]string{
+		gestureDelta(ns, ""),
+		fmt.Sprintf("if ($%s.down && Math.hypot($%s.dx, $%s.dy) <= %d) { %s }", ns, ns, ns, cfg.pressTolerance, expr),
+		gestureReset(ns),
+	}, "; ")
+
+	return Merge(
+		Signals(sig),
+		OnPointerDown(gestureDown(ns)),
+		OnPointerMove(fmt.Sprintf("if ($%s.down) { %s }", ns, gestureDelta(ns, ""))),
+		OnPointerUp(onUp),
+		OnPointerCancel(gestureReset(ns)),
+	)
+}
+
+// OnLongPress fires expr once the pointer has been held for LongPressDuration
+// (default 500ms) without releasing. The wait is scheduled with the
+// datastar-native __delay modifier rather than a shipped setTimeout, and the
+// fire check re-reads the down flag so a release before the delay elapses
+// cancels it.
+//
+//	{ ds.OnLongPress("$menu.show = true")... }
+//	{ ds.OnLongPress("$menu.show = true", ds.LongPressDuration(750*time.Millisecond))... }
+func OnLongPress(expr string, opts ...GestureOpt) templ.Attributes {
+	cfg := defaultGestureConfig()
+	for _, o := range opts {
+		o(&cfg)
+	}
+	ns, sig := gestureState()
+
+	return Merge(
+		Signals(sig),
+		OnPointerDown(gestureDown(ns)),
+		OnPointerDown(fmt.Sprintf("if ($%s.down) { %s }", ns, expr), ModDelay, Duration(cfg.longPress)),
+		OnPointerUp(gestureReset(ns)),
+		OnPointerCancel(gestureReset(ns)),
+	)
+}
+
+// OnPan fires expr on every pointermove while the pointer is down, after
+// updating the gesture's tracked dx/dy/vx/vy. PanAxis restricts tracking to
+// a single axis, zeroing the other.
+//
+//	{ ds.OnPan("$x += evt.movementX")... }
+//	{ ds.OnPan("$x += evt.movementX", ds.PanAxis("x"))... }
+func OnPan(expr string, opts ...GestureOpt) templ.Attributes {
+	cfg := defaultGestureConfig()
+	for _, o := range opts {
+		o(&cfg)
+	}
+	ns, sig := gestureState()
+
+	move := fmt.Sprintf("if ($%s.down) { %s; %s }", ns, gestureDelta(ns, cfg.panAxis), expr)
+
+	return Merge(
+		Signals(sig),
+		OnPointerDown(gestureDown(ns)),
+		OnPointerMove(move),
+		OnPointerUp(gestureReset(ns)),
+		OnPointerCancel(gestureReset(ns)),
+	)
+}
+
+// swipeCheck returns the boolean JS expression testing whether the current
+// delta clears cfg's SwipeThreshold in cfg's SwipeDirection, or, with no
+// direction set, in whichever axis moved furthest.
+func swipeCheck(ns string, cfg gestureConfig) string {
+	t := strconv.Itoa(cfg.swipeThreshold)
+	switch cfg.swipeDir {
+	case "left":
+		return fmt.Sprintf("$%s.dx <= -%s", ns, t)
+	case "right":
+		return fmt.Sprintf("$%s.dx >= %s", ns, t)
+	case "up":
+		return fmt.Sprintf("$%s.dy <= -%s", ns, t)
+	case "down":
+		return fmt.Sprintf("$%s.dy >= %s", ns, t)
+	default:
+		return fmt.Sprintf("Math.max(Math.abs($%s.dx), Math.abs($%s.dy)) >= %s", ns, ns, t)
+	}
+}
+
+// OnSwipe fires expr when a press moves at least SwipeThreshold pixels
+// (default 50) along SwipeDirection before releasing.
+//
+//	{ ds.OnSwipe("$dismiss()", ds.SwipeDirection("left"))... }
+//	{ ds.OnSwipe("$dismiss()", ds.SwipeDirection("left"), ds.SwipeThreshold(80))... }
+func OnSwipe(expr string, opts ...GestureOpt) templ.Attributes {
+	cfg := defaultGestureConfig()
+	for _, o := range opts {
+		o(&cfg)
+	}
+	ns, sig := gestureState()
+
+	onUp := strings.Join([]string{
+		gestureDelta(ns, ""),
+		fmt.Sprintf("if ($%s.down && (%s)) { %s }", ns, swipeCheck(ns, cfg), expr),
+		gestureReset(ns),
+	}, "; ")
+
+	return Merge(
+		Signals(sig),
+		OnPointerDown(gestureDown(ns)),
+		OnPointerMove(fmt.Sprintf("if ($%s.down) { %s }", ns, gestureDelta(ns, ""))),
+		OnPointerUp(onUp),
+		OnPointerCancel(gestureReset(ns)),
+	)
+}
+
+// OnHover fires expr on both pointerenter and pointerleave — the
+// pointer-unified equivalent of pairing OnMouseEnter/OnMouseLeave — after
+// updating the gesture's down flag (true on enter, false on leave) and
+// last-seen x/y.
+//
+//	{ ds.OnHover("$highlighted = !$highlighted")... }
+func OnHover(expr string, opts ...GestureOpt) templ.Attributes {
+	cfg := defaultGestureConfig()
+	for _, o := range opts {
+		o(&cfg)
+	}
+	_ = cfg // no GestureOpt currently affects OnHover; kept for signature parity
+	ns, sig := gestureState()
+
+	enter := fmt.Sprintf("$%s.down = true; $%s.x = evt.clientX; $%s.y = evt.clientY; $%s.t = Date.now(); %s", ns, ns, ns, ns, expr)
+	leave := fmt.Sprintf("%s; %s", gestureReset(ns), expr)
+
+	return Merge(
+		Signals(sig),
+		OnPointerEnter(enter),
+		OnPointerLeave(leave),
+	)
+}
@@ -0,0 +1,138 @@
+package ds_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ds "github.com/Yacobolo/datastar-templ"
+)
+
+var gestureNamespaceRE = regexp.MustCompile(`_gesture\d+`)
+
+// gestureNamespace extracts the "_gestureN" namespace a gesture helper
+// allocated, so tests don't depend on the exact (call-order-dependent)
+// number. The namespace only ever appears inside the data-signals value
+// (e.g. "{_gesture1: {...}}"), not in any attribute key.
+func gestureNamespace(t *testing.T, attrs templ.Attributes) string {
+	t.Helper()
+	for k, v := range attrs {
+		if ns := gestureNamespaceRE.FindString(k); ns != "" {
+			return ns
+		}
+		if s, ok := v.(string); ok {
+			if ns := gestureNamespaceRE.FindString(s); ns != "" {
+				return ns
+			}
+		}
+	}
+	t.Fatalf("no _gestureN signal key found in %v", attrs)
+	return ""
+}
+
+func TestOnPress(t *testing.T) {
+	attrs := ds.OnPress("$count++")
+	require.Len(t, attrs, 5)
+	ns := gestureNamespace(t, attrs)
+
+	assert.Contains(t, attrs["data-on:pointerdown"], fmt.Sprintf("$%s.down = true", ns))
+	assert.Contains(t, attrs["data-on:pointermove"], fmt.Sprintf("$%s.down", ns))
+	assert.Contains(t, attrs["data-on:pointerup"], "$count++")
+	assert.Contains(t, attrs["data-on:pointerup"], "<= 10")
+	assert.Contains(t, attrs["data-on:pointercancel"], fmt.Sprintf("$%s.down = false", ns))
+
+	t.Run("custom tolerance", func(t *testing.T) {
+		attrs := ds.OnPress("$count++", ds.PressTolerance(4))
+		assert.Contains(t, attrs["data-on:pointerup"], "<= 4")
+	})
+
+	t.Run("negative tolerance panics", func(t *testing.T) {
+		assert.Panics(t, func() { ds.PressTolerance(-1) })
+	})
+}
+
+func TestOnLongPress(t *testing.T) {
+	attrs := ds.OnLongPress("$menu.show = true")
+	require.Len(t, attrs, 5)
+	ns := gestureNamespace(t, attrs)
+
+	const delayedKey = "data-on:pointerdown__delay.500ms"
+	require.Contains(t, attrs, delayedKey)
+	assert.Contains(t, attrs[delayedKey], "$menu.show = true")
+	assert.Contains(t, attrs[delayedKey], fmt.Sprintf("$%s.down", ns))
+
+	t.Run("custom duration", func(t *testing.T) {
+		attrs := ds.OnLongPress("fire()", ds.LongPressDuration(750*time.Millisecond))
+		require.Contains(t, attrs, "data-on:pointerdown__delay.750ms")
+	})
+}
+
+func TestOnPan(t *testing.T) {
+	attrs := ds.OnPan("track()")
+	require.Len(t, attrs, 5)
+	ns := gestureNamespace(t, attrs)
+
+	assert.Contains(t, attrs["data-on:pointermove"], "track()")
+	assert.Contains(t, attrs["data-on:pointermove"], fmt.Sprintf("$%s.dx = evt.clientX - $%s.x", ns, ns))
+
+	t.Run("axis x zeroes dy", func(t *testing.T) {
+		attrs := ds.OnPan("track()", ds.PanAxis("x"))
+		ns := gestureNamespace(t, attrs)
+		assert.Contains(t, attrs["data-on:pointermove"], fmt.Sprintf("$%s.dy = 0", ns))
+	})
+
+	t.Run("invalid axis panics", func(t *testing.T) {
+		assert.Panics(t, func() { ds.PanAxis("z") })
+	})
+}
+
+func TestOnSwipe(t *testing.T) {
+	attrs := ds.OnSwipe("$dismiss()", ds.SwipeDirection("left"))
+	require.Len(t, attrs, 5)
+	ns := gestureNamespace(t, attrs)
+
+	assert.Contains(t, attrs["data-on:pointerup"], fmt.Sprintf("$%s.dx <= -50", ns))
+	assert.Contains(t, attrs["data-on:pointerup"], "$dismiss()")
+
+	t.Run("no direction checks furthest axis", func(t *testing.T) {
+		attrs := ds.OnSwipe("$dismiss()")
+		ns := gestureNamespace(t, attrs)
+		assert.Contains(t, attrs["data-on:pointerup"], fmt.Sprintf("Math.max(Math.abs($%s.dx), Math.abs($%s.dy)) >= 50", ns, ns))
+	})
+
+	t.Run("custom threshold", func(t *testing.T) {
+		attrs := ds.OnSwipe("$dismiss()", ds.SwipeDirection("right"), ds.SwipeThreshold(80))
+		ns := gestureNamespace(t, attrs)
+		assert.Contains(t, attrs["data-on:pointerup"], fmt.Sprintf("$%s.dx >= 80", ns))
+	})
+
+	t.Run("invalid direction panics", func(t *testing.T) {
+		assert.Panics(t, func() { ds.SwipeDirection("sideways") })
+	})
+
+	t.Run("non-positive threshold panics", func(t *testing.T) {
+		assert.Panics(t, func() { ds.SwipeThreshold(0) })
+	})
+}
+
+func TestOnHover(t *testing.T) {
+	attrs := ds.OnHover("$highlighted = !$highlighted")
+	require.Len(t, attrs, 3)
+	ns := gestureNamespace(t, attrs)
+
+	assert.Contains(t, attrs["data-on:pointerenter"], fmt.Sprintf("$%s.down = true", ns))
+	assert.Contains(t, attrs["data-on:pointerenter"], "$highlighted = !$highlighted")
+	assert.Contains(t, attrs["data-on:pointerleave"], fmt.Sprintf("$%s.down = false", ns))
+	assert.Contains(t, attrs["data-on:pointerleave"], "$highlighted = !$highlighted")
+}
+
+func TestGestureNamespacesDontCollide(t *testing.T) {
+	a := ds.OnPress("a()")
+	b := ds.OnPress("b()")
+	assert.NotEqual(t, gestureNamespace(t, a), gestureNamespace(t, b))
+}
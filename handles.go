@@ -0,0 +1,334 @@
+package ds
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/a-h/templ"
+)
+
+// ---------------------------------------------------------------------------
+// Typed signal handles
+//
+// Handle[T] gives call sites a typed reference to a declared signal instead
+// of a bare "$name" string, so a rename shows up as a compile error at every
+// use site instead of a silent browser-side no-op. Handles compose with the
+// existing string-based helpers (OnClick, Show, Computed, ...) via Ref/Set;
+// nothing about the runtime attribute format changes.
+// ---------------------------------------------------------------------------
+
+// Handle is a typed reference to a declared signal.
+type Handle[T any] struct {
+	name string
+}
+
+// Ref returns the "$name" reference expression.
+func (h Handle[T]) Ref() string { return "$" + h.name }
+
+// Set returns an assignment expression: "$name = expr".
+func (h Handle[T]) Set(expr string) string { return h.Ref() + " = " + expr }
+
+// Bind creates a two-way data binding for this signal.
+//
+//	<input { sig.Bind()... } />
+func (h Handle[T]) Bind(modifiers ...Modifier) templ.Attributes { return Bind(h.name, modifiers...) }
+
+// signalName lets Expr track which signals an operand references without
+// needing the operand's concrete T.
+func (h Handle[T]) signalName() string { return h.name }
+
+// NewInt declares an int signal, returning its typed handle alongside the
+// Signal value to pass into Signals(...).
+//
+//	count, countSig := ds.NewInt("count", 0)
+//	attrs := ds.Merge(ds.Signals(countSig), ds.OnClick(count.Set("$count + 1")))
+func NewInt(name string, initial int) (Handle[int], Signal) {
+	return Handle[int]{name: name}, Int(name, initial)
+}
+
+// NewString declares a string signal. See NewInt.
+func NewString(name string, initial string) (Handle[string], Signal) {
+	return Handle[string]{name: name}, String(name, initial)
+}
+
+// NewBool declares a bool signal. See NewInt.
+func NewBool(name string, initial bool) (Handle[bool], Signal) {
+	return Handle[bool]{name: name}, Bool(name, initial)
+}
+
+// NewFloat declares a float64 signal. See NewInt.
+func NewFloat(name string, initial float64) (Handle[float64], Signal) {
+	return Handle[float64]{name: name}, Float(name, initial)
+}
+
+// ---------------------------------------------------------------------------
+// Expr
+// ---------------------------------------------------------------------------
+
+// signalRef is implemented by Handle[T] for any T, letting Expr track
+// referenced signal names without depending on T.
+type signalRef interface {
+	signalName() string
+}
+
+// Expr builds a JS expression from typed operands while tracking the signal
+// names it references, so Computed/Effect callers can check that every
+// referenced signal was actually declared.
+//
+//	total := ds.ExprOf(price).Mul(ds.ExprOf(qty))
+//	ds.Computed(ds.Comp("total", total.String()))
+type Expr struct {
+	js   string
+	uses map[string]struct{}
+}
+
+// ExprOf starts an expression from a typed signal handle.
+func ExprOf[T any](h Handle[T]) Expr {
+	return Expr{js: h.Ref(), uses: map[string]struct{}{h.name: {}}}
+}
+
+// ExprLit starts an expression from a literal Go value.
+func ExprLit(v any) Expr {
+	return Expr{js: literalJS(v)}
+}
+
+// ExprRaw starts an expression from a pre-built JS snippet with no tracked
+// signal uses, for escape-hatch composition with existing string helpers.
+func ExprRaw(js string) Expr {
+	return Expr{js: js}
+}
+
+func (e Expr) binOp(op string, rhs any) Expr {
+	rhsJS, rhsUses := renderOperand(rhs)
+	out := Expr{js: e.js + " " + op + " " + rhsJS, uses: cloneUses(e.uses)}
+	mergeUses(out.uses, rhsUses)
+	return out
+}
+
+// Add returns "e + rhs".
+func (e Expr) Add(rhs any) Expr { return e.binOp("+", rhs) }
+
+// Sub returns "e - rhs".
+func (e Expr) Sub(rhs any) Expr { return e.binOp("-", rhs) }
+
+// Mul returns "e * rhs".
+func (e Expr) Mul(rhs any) Expr { return e.binOp("*", rhs) }
+
+// Div returns "e / rhs".
+func (e Expr) Div(rhs any) Expr { return e.binOp("/", rhs) }
+
+// String renders the expression's JS source.
+func (e Expr) String() string { return e.js }
+
+// RenderJS renders the expression's JS source, satisfying Expression.
+func (e Expr) RenderJS() string { return e.js }
+
+// Uses returns the sorted set of signal names this expression references.
+func (e Expr) Uses() []string {
+	names := make([]string, 0, len(e.uses))
+	for name := range e.uses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func renderOperand(v any) (string, map[string]struct{}) {
+	switch x := v.(type) {
+	case Expr:
+		return x.js, x.uses
+	case signalRef:
+		return "$" + x.signalName(), map[string]struct{}{x.signalName(): {}}
+	default:
+		return literalJS(v), nil
+	}
+}
+
+func cloneUses(uses map[string]struct{}) map[string]struct{} {
+	out := make(map[string]struct{}, len(uses))
+	for k := range uses {
+		out[k] = struct{}{}
+	}
+	return out
+}
+
+func mergeUses(dst, src map[string]struct{}) {
+	for k := range src {
+		dst[k] = struct{}{}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Expr constructors and combinators
+//
+// These build on Expr to give Class/Style/Attr/Computed pairs compile-time
+// shape safety instead of hand-written JS strings, while rendering to the
+// exact same text the string-based helpers (C, S, A, Comp) already produce.
+// ---------------------------------------------------------------------------
+
+// Sig creates an expression referencing a signal by name, without requiring
+// a declared Handle[T].
+//
+//	ds.Show(ds.Sig("visible").String())
+func Sig(name string) Expr {
+	return Expr{js: "$" + name, uses: map[string]struct{}{name: {}}}
+}
+
+// Not negates a boolean expression: "!(e)".
+func Not(e Expr) Expr {
+	return Expr{js: "!(" + e.js + ")", uses: cloneUses(e.uses)}
+}
+
+// And returns "a && b".
+func And(a, b Expr) Expr { return combineExpr(a, "&&", b) }
+
+// Eq returns "a === b".
+func Eq(a, b Expr) Expr { return combineExpr(a, "===", b) }
+
+// Ternary returns "(cond ? a : b)".
+func Ternary(cond, a, b Expr) Expr {
+	out := Expr{js: "(" + cond.js + " ? " + a.js + " : " + b.js + ")", uses: cloneUses(cond.uses)}
+	mergeUses(out.uses, a.uses)
+	mergeUses(out.uses, b.uses)
+	return out
+}
+
+// Lit wraps a Go value as a literal expression; equivalent to ExprLit.
+func Lit(v any) Expr { return ExprLit(v) }
+
+// Call returns a function-call expression: "fn(args...)".
+func Call(fn string, args ...Expr) Expr {
+	js := fn + "("
+	uses := map[string]struct{}{}
+	for i, a := range args {
+		if i > 0 {
+			js += ", "
+		}
+		js += a.js
+		mergeUses(uses, a.uses)
+	}
+	js += ")"
+	return Expr{js: js, uses: uses}
+}
+
+func combineExpr(a Expr, op string, b Expr) Expr {
+	out := Expr{js: a.js + " " + op + " " + b.js, uses: cloneUses(a.uses)}
+	mergeUses(out.uses, b.uses)
+	return out
+}
+
+// BoolExpr and StringExpr document the expected shape of an expression
+// passed to CE/SE/AE/CompE; both are Expr under the hood.
+type (
+	BoolExpr   = Expr
+	StringExpr = Expr
+)
+
+// CE creates a class binding pair from a typed expression, the Expr
+// counterpart to C.
+//
+//	ds.Class(ds.CE("hidden", ds.Not(ds.Sig("visible"))))
+func CE(class string, cond BoolExpr) ClassPair {
+	return ClassPair{class, cond.String()}
+}
+
+// SE creates a style binding pair from a typed expression, the Expr
+// counterpart to S.
+func SE(prop string, val StringExpr) StylePair {
+	return StylePair{prop, val.String()}
+}
+
+// AE creates an HTML attribute binding pair from a typed expression, the
+// Expr counterpart to A.
+func AE(attr string, val Expr) AttrPair {
+	return AttrPair{attr, val.String()}
+}
+
+// CompE creates a computed signal pair from a typed expression, the Expr
+// counterpart to Comp.
+func CompE(name string, val Expr) ComputedPair {
+	return ComputedPair{name, val.String()}
+}
+
+// ---------------------------------------------------------------------------
+// Expression — the typed-or-string escape hatch
+//
+// Expression lets a handful of the most common expression-valued helpers
+// accept either a plain string (today's behavior, unchanged) or an Expr
+// built from Sig/Lit/Call/etc. The *Expr variants below are the Expr
+// counterparts to the existing string-based helpers, following the same
+// naming convention as CE/SE/AE/CompE.
+// ---------------------------------------------------------------------------
+
+// Expression is satisfied by any value that renders to a JS expression
+// string. Expr implements it; so does any caller-defined type.
+type Expression interface {
+	RenderJS() string
+}
+
+// Assign returns "target = value", e.g. for use with OnClick/Effect.
+//
+//	ds.OnClickExpr(ds.Assign(ds.Sig("total"), ds.Reduce(ds.Sig("items"), "sum", "item", "sum + item.price", 0)))
+func Assign(target, value Expression) Expr {
+	out := Expr{js: target.RenderJS() + " = " + value.RenderJS()}
+	if e, ok := target.(Expr); ok {
+		out.uses = cloneUses(e.uses)
+	}
+	if e, ok := value.(Expr); ok {
+		if out.uses == nil {
+			out.uses = cloneUses(e.uses)
+		} else {
+			mergeUses(out.uses, e.uses)
+		}
+	}
+	return out
+}
+
+// Reduce returns "arr.reduce((acc, item) => body, initial)".
+//
+//	ds.Reduce(ds.Sig("items"), "sum", "item", "sum + item.price", 0)
+//	// -> "$items.reduce((sum, item) => sum + item.price, 0)"
+func Reduce(arr Expression, accName, itemName, body string, initial any) Expr {
+	out := Expr{js: fmt.Sprintf("%s.reduce((%s, %s) => %s, %s)", arr.RenderJS(), accName, itemName, body, literalJS(initial))}
+	if e, ok := arr.(Expr); ok {
+		out.uses = cloneUses(e.uses)
+	}
+	return out
+}
+
+// ShowExpr is the Expr counterpart to Show.
+func ShowExpr(e Expression) templ.Attributes { return Show(e.RenderJS()) }
+
+// TextExpr is the Expr counterpart to Text.
+func TextExpr(e Expression) templ.Attributes { return Text(e.RenderJS()) }
+
+// EffectExpr is the Expr counterpart to Effect.
+func EffectExpr(e Expression) templ.Attributes { return Effect(e.RenderJS()) }
+
+// OnClickExpr is the Expr counterpart to OnClick.
+func OnClickExpr(e Expression, modifiers ...Modifier) templ.Attributes {
+	return OnClick(e.RenderJS(), modifiers...)
+}
+
+// literalJS renders a Go value as a JS literal, matching the encoding rules
+// used by the Int/String/Bool/Float/JSON signal constructors.
+func literalJS(v any) string {
+	switch x := v.(type) {
+	case string:
+		return strconv.Quote(x)
+	case bool:
+		return strconv.FormatBool(x)
+	case int:
+		return strconv.Itoa(x)
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			panic(fmt.Sprintf("ds: failed to render literal: %v", err))
+		}
+		return string(data)
+	}
+}
@@ -0,0 +1,87 @@
+package ds_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ds "github.com/Yacobolo/datastar-templ"
+)
+
+func TestHandleRefAndSet(t *testing.T) {
+	count, sig := ds.NewInt("count", 0)
+
+	assert.Equal(t, "$count", count.Ref())
+	assert.Equal(t, "$count = $count + 1", count.Set("$count + 1"))
+	assert.Equal(t, ds.Int("count", 0), sig)
+}
+
+func TestHandleBind(t *testing.T) {
+	name, _ := ds.NewString("name", "")
+	attrs := name.Bind()
+	assert.Equal(t, true, attrs["data-bind:name"])
+}
+
+func TestExprOf(t *testing.T) {
+	price, _ := ds.NewFloat("price", 1)
+	qty, _ := ds.NewInt("qty", 1)
+
+	total := ds.ExprOf(price).Mul(ds.ExprOf(qty))
+	assert.Equal(t, "$price * $qty", total.String())
+	assert.Equal(t, []string{"price", "qty"}, total.Uses())
+}
+
+func TestExprWithLiteral(t *testing.T) {
+	discount, _ := ds.NewFloat("discount", 0)
+
+	expr := ds.ExprOf(discount).Mul(0.1).Add(ds.ExprLit("$fee"))
+	assert.Equal(t, `$discount * 0.1 + "$fee"`, expr.String())
+	assert.Equal(t, []string{"discount"}, expr.Uses())
+}
+
+func TestExprRaw(t *testing.T) {
+	expr := ds.ExprRaw("$count * 2")
+	assert.Equal(t, "$count * 2", expr.String())
+	assert.Empty(t, expr.Uses())
+}
+
+func TestExprCombinators(t *testing.T) {
+	assert.Equal(t, "$visible", ds.Sig("visible").String())
+	assert.Equal(t, "!($visible)", ds.Not(ds.Sig("visible")).String())
+	assert.Equal(t, "$a && $b", ds.And(ds.Sig("a"), ds.Sig("b")).String())
+	assert.Equal(t, "$a === $b", ds.Eq(ds.Sig("a"), ds.Sig("b")).String())
+	assert.Equal(t, "($ok ? $a : $b)", ds.Ternary(ds.Sig("ok"), ds.Sig("a"), ds.Sig("b")).String())
+	assert.Equal(t, `"hidden"`, ds.Lit("hidden").String())
+	assert.Equal(t, `fn($a, "x")`, ds.Call("fn", ds.Sig("a"), ds.Lit("x")).String())
+	assert.ElementsMatch(t, []string{"a", "b", "ok"}, ds.Ternary(ds.Sig("ok"), ds.Sig("a"), ds.Sig("b")).Uses())
+}
+
+func TestAssignAndReduce(t *testing.T) {
+	total := ds.Reduce(ds.Sig("items"), "sum", "item", "sum + item.price", 0)
+	assert.Equal(t, "$items.reduce((sum, item) => sum + item.price, 0)", total.RenderJS())
+
+	assign := ds.Assign(ds.Sig("total"), total)
+	assert.Equal(t, "$total = $items.reduce((sum, item) => sum + item.price, 0)", assign.RenderJS())
+	assert.ElementsMatch(t, []string{"total", "items"}, assign.Uses())
+}
+
+func TestExprNativeAttributeHelpers(t *testing.T) {
+	assert.Equal(t, "$visible", ds.ShowExpr(ds.Sig("visible"))["data-show"])
+	assert.Equal(t, "$count", ds.TextExpr(ds.Sig("count"))["data-text"])
+	assert.Equal(t, "$total = $price * $qty", ds.EffectExpr(ds.Assign(ds.Sig("total"), ds.Sig("price").Mul(ds.Sig("qty"))))["data-effect"])
+	assert.Equal(t, "$open = true", ds.OnClickExpr(ds.ExprRaw("$open = true"))["data-on:click"])
+}
+
+func TestTypedPairConstructors(t *testing.T) {
+	attrs := ds.Class(ds.CE("hidden", ds.Not(ds.Sig("visible"))))
+	assert.Equal(t, `{'hidden': !($visible)}`, attrs["data-class"])
+
+	attrs = ds.Style(ds.SE("display", ds.Ternary(ds.Sig("hiding"), ds.Lit("none"), ds.Lit(""))))
+	assert.Equal(t, `{'display': ($hiding ? "none" : "")}`, attrs["data-style"])
+
+	attrs = ds.Attr(ds.AE("disabled", ds.Sig("loading")))
+	assert.Equal(t, `{'disabled': $loading}`, attrs["data-attr"])
+
+	attrs = ds.Computed(ds.CompE("total", ds.Sig("price").Mul(ds.Sig("qty"))))
+	assert.Equal(t, `{'total': () => $price * $qty}`, attrs["data-computed"])
+}
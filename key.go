@@ -0,0 +1,82 @@
+package ds
+
+import "strings"
+
+// ---------------------------------------------------------------------------
+// Typed keyboard modifiers
+//
+// OnKeyDown/OnKeyUp/OnKeyPress accept the same free-form Modifier as every
+// other On* helper, so a key filter has always been whatever the caller
+// typed by hand: ".ctrl.enter", ".meta.k", or a typo that only surfaces at
+// runtime in the browser. Key builds that modifier from typed pieces
+// instead, and the KeyXxx/KeyCtrl etc. constants below cover the named keys
+// and chord modifiers Datastar's on:key filter recognizes.
+// ---------------------------------------------------------------------------
+
+// KeyName is a keyboard key, matching KeyboardEvent.key lowercased (the form
+// Datastar's on:key filter expects). The KeyXxx constants cover the common
+// named keys; a single printable character also works directly since
+// KeyName's underlying type is string, e.g. KeyName("k").
+type KeyName string
+
+// Named keys commonly filtered on.
+const (
+	KeyEnter      KeyName = "enter"
+	KeyEscape     KeyName = "escape"
+	KeyTab        KeyName = "tab"
+	KeySpace      KeyName = "space"
+	KeyArrowUp    KeyName = "arrowup"
+	KeyArrowDown  KeyName = "arrowdown"
+	KeyArrowLeft  KeyName = "arrowleft"
+	KeyArrowRight KeyName = "arrowright"
+	KeyPageUp     KeyName = "pageup"
+	KeyPageDown   KeyName = "pagedown"
+	KeyHome       KeyName = "home"
+	KeyEnd        KeyName = "end"
+	KeyBackspace  KeyName = "backspace"
+	KeyDelete     KeyName = "delete"
+)
+
+// Function keys.
+const (
+	KeyF1  KeyName = "f1"
+	KeyF2  KeyName = "f2"
+	KeyF3  KeyName = "f3"
+	KeyF4  KeyName = "f4"
+	KeyF5  KeyName = "f5"
+	KeyF6  KeyName = "f6"
+	KeyF7  KeyName = "f7"
+	KeyF8  KeyName = "f8"
+	KeyF9  KeyName = "f9"
+	KeyF10 KeyName = "f10"
+	KeyF11 KeyName = "f11"
+	KeyF12 KeyName = "f12"
+)
+
+// KeyModifier is a chord key combined with a KeyName by Key.
+type KeyModifier string
+
+// Chord modifiers.
+const (
+	KeyCtrl  KeyModifier = "ctrl"
+	KeyShift KeyModifier = "shift"
+	KeyAlt   KeyModifier = "alt"
+	KeyMeta  KeyModifier = "meta"
+	KeyCmd   KeyModifier = "cmd"
+)
+
+// Key builds a key-filter modifier for OnKeyDown/OnKeyUp/OnKeyPress from a
+// key name and optional chord modifiers, rendering "{.mod}....{name}":
+//
+//	ds.OnKeyDown("@post('/save')", ds.Key(ds.KeyEnter, ds.KeyCtrl)) // -> ".ctrl.enter"
+//	ds.OnKeyDown("closeModal()", ds.Key(ds.KeyEscape))              // -> ".escape"
+func Key(name KeyName, mods ...KeyModifier) Modifier {
+	var b strings.Builder
+	for _, m := range mods {
+		b.WriteByte('.')
+		b.WriteString(string(m))
+	}
+	b.WriteByte('.')
+	b.WriteString(string(name))
+	return Modifier(b.String())
+}
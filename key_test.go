@@ -0,0 +1,32 @@
+package ds_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ds "github.com/Yacobolo/datastar-templ"
+)
+
+func TestKey(t *testing.T) {
+	t.Run("bare key", func(t *testing.T) {
+		assert.Equal(t, ds.Modifier(".enter"), ds.Key(ds.KeyEnter))
+	})
+
+	t.Run("single chord", func(t *testing.T) {
+		assert.Equal(t, ds.Modifier(".ctrl.enter"), ds.Key(ds.KeyEnter, ds.KeyCtrl))
+	})
+
+	t.Run("multiple chords preserve order", func(t *testing.T) {
+		assert.Equal(t, ds.Modifier(".ctrl.shift.enter"), ds.Key(ds.KeyEnter, ds.KeyCtrl, ds.KeyShift))
+	})
+
+	t.Run("meta chord with a plain letter key", func(t *testing.T) {
+		assert.Equal(t, ds.Modifier(".meta.k"), ds.Key(ds.KeyName("k"), ds.KeyMeta))
+	})
+
+	t.Run("composes with OnKeyDown", func(t *testing.T) {
+		attrs := ds.OnKeyDown("@post('/save')", ds.Key(ds.KeyEnter, ds.KeyCtrl))
+		assert.Equal(t, "@post('/save')", attrs["data-on:keydown.ctrl.enter"])
+	})
+}
@@ -0,0 +1,267 @@
+package ds
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/a-h/templ"
+)
+
+// MergeStrict combines attrs like Merge, but fails if any key is produced by
+// more than one input instead of silently keeping the last value. It is
+// MergeWith(ErrorOnConflict, ...) under a name that matches the common case.
+//
+//	ds.MergeStrict(ds.Show("$first"), ds.Show("$second"))
+//	// -> nil, error: ds: conflicting attribute "data-show"
+func MergeStrict(attrs ...templ.Attributes) (templ.Attributes, error) {
+	return MergeWith(ErrorOnConflict, attrs...)
+}
+
+// MergeSmart combines attrs like Merge, but with several attribute kinds
+// handled domain-aware rather than last-wins:
+//
+//   - data-class: entries for the same class name across inputs are OR'd
+//     together ("(a) || (b)") instead of one input's data-class object
+//     clobbering another's.
+//   - data-attr, data-style, data-signals: entries for the same sub-key
+//     (HTML attribute, CSS property, or signal name) across inputs are
+//     unioned into one object literal; the same sub-key bound to two
+//     different expressions is an error, since unlike classes there's no
+//     sensible way to combine two different attribute/style/signal values.
+//   - data-on:*: an exact key collision (the same event and modifier suffix
+//     bound to two different expressions) is an error; the same event with
+//     differing modifiers is unaffected, since each modifier suffix already
+//     produces a distinct key under plain Merge.
+//
+// Every other key falls back to Merge's last-wins behavior.
+func MergeSmart(attrs ...templ.Attributes) (templ.Attributes, error) {
+	m := make(templ.Attributes)
+	classExprs := make(map[string][]string)
+	var classOrder []string
+
+	union := map[string]*unionedObject{
+		prefix + attrAttr:    {},
+		prefix + attrStyle:   {},
+		prefix + attrSignals: {bareKeys: true},
+	}
+
+	for _, a := range attrs {
+		for k, v := range a {
+			if k == prefix+attrClass {
+				s, ok := v.(string)
+				if !ok {
+					return nil, fmt.Errorf("ds: MergeSmart: data-class value must be a string, got %T", v)
+				}
+				pairs, err := parseObjectPairs(s)
+				if err != nil {
+					return nil, fmt.Errorf("ds: MergeSmart: %w", err)
+				}
+				for _, p := range pairs {
+					if _, seen := classExprs[p.key]; !seen {
+						classOrder = append(classOrder, p.key)
+					}
+					classExprs[p.key] = append(classExprs[p.key], p.expr)
+				}
+				continue
+			}
+
+			if u, ok := union[k]; ok {
+				s, ok := v.(string)
+				if !ok {
+					return nil, fmt.Errorf("ds: MergeSmart: %q value must be a string, got %T", k, v)
+				}
+				pairs, err := parseObjectPairs(s)
+				if err != nil {
+					return nil, fmt.Errorf("ds: MergeSmart: %w", err)
+				}
+				if err := u.add(pairs); err != nil {
+					return nil, fmt.Errorf("ds: MergeSmart: conflicting %q entry %w", k, err)
+				}
+				continue
+			}
+
+			if strings.HasPrefix(k, prefixOn) {
+				if existing, ok := m[k]; ok && existing != v {
+					return nil, fmt.Errorf("ds: MergeSmart: conflicting handlers for %q", k)
+				}
+				m[k] = v
+				continue
+			}
+
+			m[k] = v
+		}
+	}
+
+	if len(classOrder) > 0 {
+		var b strings.Builder
+		b.WriteByte('{')
+		for i, name := range classOrder {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			exprs := classExprs[name]
+			joined := exprs[0]
+			if len(exprs) > 1 {
+				joined = "(" + strings.Join(exprs, ") || (") + ")"
+			}
+			fmt.Fprintf(&b, "'%s': %s", name, joined)
+		}
+		b.WriteByte('}')
+		m[prefix+attrClass] = b.String()
+	}
+
+	for k, u := range union {
+		if rendered, ok := u.render(); ok {
+			m[k] = rendered
+		}
+	}
+
+	return m, nil
+}
+
+// unionedObject accumulates the sub-key/expr entries of an object-literal
+// attribute (data-attr, data-style, data-signals) across multiple MergeSmart
+// inputs, erroring on the first sub-key set to two different expressions.
+type unionedObject struct {
+	order []string
+	exprs map[string]string
+	// bareKeys renders keys unquoted (matching Signals' "name: value" form)
+	// instead of single-quoted (matching Class/Attr/Style's "'name': value").
+	bareKeys bool
+}
+
+func (u *unionedObject) add(pairs []objPair) error {
+	if u.exprs == nil {
+		u.exprs = make(map[string]string, len(pairs))
+	}
+	for _, p := range pairs {
+		if existing, seen := u.exprs[p.key]; seen {
+			if existing != p.expr {
+				return fmt.Errorf("%q", p.key)
+			}
+			continue
+		}
+		u.order = append(u.order, p.key)
+		u.exprs[p.key] = p.expr
+	}
+	return nil
+}
+
+func (u *unionedObject) render() (string, bool) {
+	if len(u.order) == 0 {
+		return "", false
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, key := range u.order {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		if u.bareKeys {
+			fmt.Fprintf(&b, "%s: %s", jsKey(key), u.exprs[key])
+		} else {
+			fmt.Fprintf(&b, "'%s': %s", key, u.exprs[key])
+		}
+	}
+	b.WriteByte('}')
+	return b.String(), true
+}
+
+// objPair is one "'key': expr" entry parsed out of a JS object literal
+// produced by Class/Style/Attr/Computed.
+type objPair struct {
+	key  string
+	expr string
+}
+
+// parseObjectPairs splits a "{'k1': v1, 'k2': v2}" literal (as produced by
+// Class/Style/Attr/Computed) back into its key/expr pairs, respecting nested
+// parens/brackets/braces and quoted strings inside each expr.
+func parseObjectPairs(s string) ([]objPair, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "{") || !strings.HasSuffix(s, "}") {
+		return nil, fmt.Errorf("expected a JS object literal, got %q", s)
+	}
+	inner := s[1 : len(s)-1]
+	if strings.TrimSpace(inner) == "" {
+		return nil, nil
+	}
+
+	var raw []string
+	depth := 0
+	var quote rune
+	start := 0
+	runes := []rune(inner)
+	for i, r := range runes {
+		switch {
+		case quote != 0:
+			if r == quote && trailingBackslashes(runes, i)%2 == 0 {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '(' || r == '[' || r == '{':
+			depth++
+		case r == ')' || r == ']' || r == '}':
+			depth--
+		case r == ',' && depth == 0:
+			raw = append(raw, string(runes[start:i]))
+			start = i + 1
+		}
+	}
+	raw = append(raw, string(runes[start:]))
+
+	pairs := make([]objPair, 0, len(raw))
+	for _, entry := range raw {
+		p, err := splitObjectPair(entry)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, p)
+	}
+	return pairs, nil
+}
+
+// trailingBackslashes counts the contiguous '\\' runes immediately
+// preceding index i. A quote at i is escaped if this count is odd
+// ("\\'" escapes the quote) and unescaped if it's even, including zero
+// ("\\\\'" is an escaped backslash followed by an unescaped quote).
+func trailingBackslashes(runes []rune, i int) int {
+	n := 0
+	for j := i - 1; j >= 0 && runes[j] == '\\'; j-- {
+		n++
+	}
+	return n
+}
+
+// splitObjectPair parses a single "'key': expr" entry (Class/Style/Attr's
+// always-quoted form) or a bare "key: expr" entry (Signals' form, rendered
+// by jsKey as an unquoted identifier or a double-quoted string).
+func splitObjectPair(raw string) (objPair, error) {
+	s := strings.TrimSpace(raw)
+	if s == "" {
+		return objPair{}, fmt.Errorf("empty object literal entry")
+	}
+
+	var key, rest string
+	switch s[0] {
+	case '\'', '"':
+		end := strings.IndexByte(s[1:], s[0])
+		if end < 0 {
+			return objPair{}, fmt.Errorf("unterminated key in %q", s)
+		}
+		key = s[1 : end+1]
+		rest = s[end+2:]
+	default:
+		idx := strings.IndexByte(s, ':')
+		if idx < 0 {
+			return objPair{}, fmt.Errorf("expected \"key: value\", got %q", s)
+		}
+		key = strings.TrimSpace(s[:idx])
+		rest = s[idx+1:]
+	}
+
+	rest = strings.TrimSpace(rest)
+	rest = strings.TrimPrefix(rest, ":")
+	return objPair{key: key, expr: strings.TrimSpace(rest)}, nil
+}
@@ -0,0 +1,160 @@
+package ds_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ds "github.com/Yacobolo/datastar-templ"
+)
+
+func TestMergeStrict(t *testing.T) {
+	t.Run("no conflict passes through", func(t *testing.T) {
+		result, err := ds.MergeStrict(ds.Show("$open"), ds.OnClick("close()"))
+		require.NoError(t, err)
+		assert.Equal(t, "$open", result["data-show"])
+		assert.Equal(t, "close()", result["data-on:click"])
+	})
+
+	t.Run("duplicate key fails", func(t *testing.T) {
+		_, err := ds.MergeStrict(ds.Show("$first"), ds.Show("$second"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "data-show")
+	})
+
+	t.Run("duplicate ClassKey fails", func(t *testing.T) {
+		_, err := ds.MergeStrict(
+			ds.ClassKey("active", "$a"),
+			ds.ClassKey("active", "$b"),
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "data-class:active")
+	})
+}
+
+func TestMergeSmart(t *testing.T) {
+	t.Run("ORs expressions for the same class across inputs", func(t *testing.T) {
+		result, err := ds.MergeSmart(
+			ds.Class(ds.C("active", "$a")),
+			ds.Class(ds.C("active", "$b")),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "{'active': ($a) || ($b)}", result["data-class"])
+	})
+
+	t.Run("keeps unrelated classes from different inputs", func(t *testing.T) {
+		result, err := ds.MergeSmart(
+			ds.Class(ds.C("active", "$a")),
+			ds.Class(ds.C("hidden", "$b")),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "{'active': $a, 'hidden': $b}", result["data-class"])
+	})
+
+	t.Run("single class input passes through unchanged", func(t *testing.T) {
+		result, err := ds.MergeSmart(ds.Class(ds.C("active", "$a")))
+		require.NoError(t, err)
+		assert.Equal(t, "{'active': $a}", result["data-class"])
+	})
+
+	t.Run("same event, differing modifiers stay distinct", func(t *testing.T) {
+		result, err := ds.MergeSmart(
+			ds.OnClick("first()", ds.ModDebounce, ds.Ms(100)),
+			ds.OnClick("second()", ds.ModThrottle, ds.Ms(200)),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "first()", result["data-on:click__debounce.100ms"])
+		assert.Equal(t, "second()", result["data-on:click__throttle.200ms"])
+	})
+
+	t.Run("same event, same modifiers, same expr is fine", func(t *testing.T) {
+		result, err := ds.MergeSmart(
+			ds.OnClick("handler()"),
+			ds.OnClick("handler()"),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "handler()", result["data-on:click"])
+	})
+
+	t.Run("same event, same modifiers, conflicting expr fails", func(t *testing.T) {
+		_, err := ds.MergeSmart(
+			ds.OnClick("first()"),
+			ds.OnClick("second()"),
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "data-on:click")
+	})
+
+	t.Run("other keys fall back to last-wins", func(t *testing.T) {
+		result, err := ds.MergeSmart(ds.Show("$first"), ds.Show("$second"))
+		require.NoError(t, err)
+		assert.Equal(t, "$second", result["data-show"])
+	})
+
+	t.Run("unions data-attr entries from different inputs", func(t *testing.T) {
+		result, err := ds.MergeSmart(
+			ds.Attr(ds.A("title", "$tooltip")),
+			ds.Attr(ds.A("disabled", "$loading")),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "{'title': $tooltip, 'disabled': $loading}", result["data-attr"])
+	})
+
+	t.Run("same data-attr key, same expr is fine", func(t *testing.T) {
+		result, err := ds.MergeSmart(
+			ds.Attr(ds.A("title", "$tooltip")),
+			ds.Attr(ds.A("title", "$tooltip")),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "{'title': $tooltip}", result["data-attr"])
+	})
+
+	t.Run("same data-attr key, conflicting expr fails", func(t *testing.T) {
+		_, err := ds.MergeSmart(
+			ds.Attr(ds.A("title", "$a")),
+			ds.Attr(ds.A("title", "$b")),
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "data-attr")
+		assert.Contains(t, err.Error(), "title")
+	})
+
+	t.Run("unions data-style entries from different inputs", func(t *testing.T) {
+		result, err := ds.MergeSmart(
+			ds.Style(ds.S("color", "$color")),
+			ds.Style(ds.S("opacity", "$opacity")),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "{'color': $color, 'opacity': $opacity}", result["data-style"])
+	})
+
+	t.Run("unions data-signals entries with bare keys", func(t *testing.T) {
+		result, err := ds.MergeSmart(
+			ds.Signals(ds.Int("count", 1)),
+			ds.Signals(ds.String("message", "hi")),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, `{count: 1, message: "hi"}`, result["data-signals"])
+	})
+
+	t.Run("same data-signals key, conflicting expr fails", func(t *testing.T) {
+		_, err := ds.MergeSmart(
+			ds.Signals(ds.Int("count", 1)),
+			ds.Signals(ds.Int("count", 2)),
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "data-signals")
+		assert.Contains(t, err.Error(), "count")
+	})
+
+	t.Run("trailing backslash in a string value doesn't swallow the next entry", func(t *testing.T) {
+		_, err := ds.MergeSmart(
+			ds.Signals(ds.String("message", `a\`), ds.Int("count", 1)),
+			ds.Signals(ds.Int("count", 99)),
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "data-signals")
+		assert.Contains(t, err.Error(), "count")
+	})
+}
@@ -0,0 +1,177 @@
+package ds
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/a-h/templ"
+)
+
+// ---------------------------------------------------------------------------
+// RegisterSchema / Validate
+//
+// Schema (expr_schema.go) checks an Expr's tracked Uses() against declared
+// signals, but most helpers (Text, Show, Effect, Class, ...) still take raw
+// JS strings with no tracked uses at all. RegisterSchema/Validate cover that
+// remaining surface: scan a rendered templ.Attributes' string values for
+// "$identifier" references directly, the same way a reviewer would eyeball a
+// diff for a typo'd "$quantiy".
+// ---------------------------------------------------------------------------
+
+var registeredSchema atomic.Pointer[Schema]
+
+// RegisterSchema declares the process-wide signal schema Validate checks
+// attributes against, keyed by signal name.
+//
+//	ds.RegisterSchema(map[string]ds.SignalKind{
+//		"price": ds.KindFloat,
+//		"qty":   ds.KindInt,
+//	})
+func RegisterSchema(kinds map[string]SignalKind) Schema {
+	s := Schema{kinds: make(map[string]SignalKind, len(kinds))}
+	for name, kind := range kinds {
+		s.kinds[name] = kind
+	}
+	registeredSchema.Store(&s)
+	return s
+}
+
+// Validate scans attrs' string values for "$name" and dotted "$a.b"
+// references (as Bind("table.search") produces) and checks each against the
+// schema registered via RegisterSchema. Identifiers inside JS string
+// literals are ignored, since those aren't signal references.
+//
+// Under Strict(), an undeclared reference is returned as an error. Outside
+// strict mode it's logged via the standard logger and Validate returns nil.
+// If no schema has been registered, Validate is a no-op.
+func Validate(ctx context.Context, attrs templ.Attributes) error {
+	s := registeredSchema.Load()
+	if s == nil {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	var undeclared []string
+	check := func(name string) {
+		if _, declared := s.kinds[name]; declared || seen[name] {
+			return
+		}
+		seen[name] = true
+		undeclared = append(undeclared, name)
+	}
+
+	for key, raw := range attrs {
+		if name, ok := keyedSignalName(key); ok {
+			check(name)
+		}
+		if str, ok := raw.(string); ok {
+			for _, name := range scanSignalRefs(str) {
+				check(name)
+			}
+		}
+	}
+	if len(undeclared) == 0 {
+		return nil
+	}
+	sort.Strings(undeclared)
+
+	msg := fmt.Sprintf("ds: undeclared signal(s) referenced: %s", strings.Join(undeclared, ", "))
+	if Strict() {
+		return fmt.Errorf("%s", msg)
+	}
+	log.Println(msg)
+	return nil
+}
+
+// signalKeyPrefixes are the keyed-attribute prefixes (see keyed() in ds.go)
+// whose key segment is itself a signal name/path rather than a CSS class,
+// HTML attribute, or CSS property name — attrClass/attrAttr/attrStyle use
+// the same keyed() builder but aren't signal references.
+var signalKeyPrefixes = []string{
+	prefix + attrBind + sepColon,
+	prefix + attrComputed + sepColon,
+}
+
+// keyedSignalName extracts the signal name/path from a keyed attribute name
+// such as "data-bind:table.search", stripping any trailing __modifier
+// suffix. A dot-tag modifier (".kebab", ".case=...") placed directly after a
+// dotted signal path can't be distinguished from the path itself by this
+// best-effort scan — Bind/Computed callers that rely on those should expect
+// a possible false positive rather than a missed reference.
+func keyedSignalName(key string) (string, bool) {
+	for _, p := range signalKeyPrefixes {
+		if name, ok := strings.CutPrefix(key, p); ok {
+			if i := strings.Index(name, "__"); i >= 0 {
+				name = name[:i]
+			}
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// scanSignalRefs returns every "$identifier[.identifier...]" reference in
+// js, skipping text inside '...'/"..."/`...` string literals.
+func scanSignalRefs(js string) []string {
+	stripped := stripJSStringLiterals(js)
+
+	var refs []string
+	for i := 0; i < len(stripped); i++ {
+		if stripped[i] != '$' {
+			continue
+		}
+		start := i + 1
+		j := start
+		for j < len(stripped) && isSignalPathByte(stripped[j]) {
+			j++
+		}
+		if j > start {
+			refs = append(refs, stripped[start:j])
+		}
+		i = j
+	}
+	return refs
+}
+
+func isSignalPathByte(b byte) bool {
+	return b == '_' || b == '.' ||
+		(b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+// stripJSStringLiterals blanks out the contents of '...'/"..."/`...` string
+// literals in js (preserving length and non-string characters), so
+// scanSignalRefs doesn't mistake a "$" inside a string for a signal
+// reference.
+func stripJSStringLiterals(js string) string {
+	var b strings.Builder
+	b.Grow(len(js))
+	inString := false
+	var quote byte
+	for i := 0; i < len(js); i++ {
+		c := js[i]
+		switch {
+		case inString:
+			if c == '\\' && i+1 < len(js) {
+				b.WriteByte(' ')
+				i++
+				b.WriteByte(' ')
+				continue
+			}
+			if c == quote {
+				inString = false
+			}
+			b.WriteByte(' ')
+		case c == '\'' || c == '"' || c == '`':
+			inString = true
+			quote = c
+			b.WriteByte(' ')
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
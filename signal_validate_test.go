@@ -0,0 +1,62 @@
+package ds_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/a-h/templ"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ds "github.com/Yacobolo/datastar-templ"
+)
+
+func TestValidate(t *testing.T) {
+	ds.RegisterSchema(map[string]ds.SignalKind{
+		"qty":   ds.KindInt,
+		"price": ds.KindFloat,
+	})
+
+	t.Run("passes when every reference is declared", func(t *testing.T) {
+		attrs := ds.Show("$qty > 0 && $price > 0")
+		assert.NoError(t, ds.Validate(context.Background(), attrs))
+	})
+
+	t.Run("dotted signal path from Bind", func(t *testing.T) {
+		attrs := ds.Bind("table.search")
+		ds.RegisterSchema(map[string]ds.SignalKind{"table.search": ds.KindString})
+		assert.NoError(t, ds.Validate(context.Background(), attrs))
+		ds.RegisterSchema(map[string]ds.SignalKind{"qty": ds.KindInt, "price": ds.KindFloat})
+	})
+
+	t.Run("ignores identifiers inside string literals", func(t *testing.T) {
+		attrs := ds.OnClick(`console.log("$notASignal")`)
+		assert.NoError(t, ds.Validate(context.Background(), attrs))
+	})
+
+	t.Run("logs instead of failing outside strict mode", func(t *testing.T) {
+		attrs := ds.Show("$quantiy > 0")
+		assert.NoError(t, ds.Validate(context.Background(), attrs))
+	})
+
+	t.Run("returns an error under strict mode", func(t *testing.T) {
+		ds.SetStrict(true)
+		defer ds.SetStrict(false)
+		attrs := ds.Show("$quantiy > 0")
+		err := ds.Validate(context.Background(), attrs)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "quantiy")
+	})
+}
+
+func TestScanSignalRefsViaValidate(t *testing.T) {
+	ds.RegisterSchema(map[string]ds.SignalKind{"count": ds.KindInt})
+	ds.SetStrict(true)
+	defer ds.SetStrict(false)
+
+	attrs := templ.Attributes{"data-text": "$count"}
+	assert.NoError(t, ds.Validate(context.Background(), attrs))
+
+	attrs = templ.Attributes{"data-text": "$missing"}
+	assert.Error(t, ds.Validate(context.Background(), attrs))
+}
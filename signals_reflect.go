@@ -0,0 +1,279 @@
+package ds
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/a-h/templ"
+)
+
+// ---------------------------------------------------------------------------
+// Struct-based signals
+//
+// Struct and SignalsFrom walk an arbitrary Go value via reflection and emit
+// the nested data-signals object Datastar expects, e.g. a struct with a
+// nested struct field renders as {user: {name: "a", age: 1}, count: 2}
+// rather than the flat top-level keys Int/String/Bool/Float/JSON produce.
+// ---------------------------------------------------------------------------
+
+// Struct creates a nested-object signal from an arbitrary Go value (struct,
+// map, slice, or any mix of those) via reflection. Field names default to
+// lower-camel-case and can be overridden with a `datastar:"name"` tag; see
+// SignalsFrom for the full tag syntax.
+//
+//	ds.Signals(ds.Struct("user", User{Name: "Ada", Age: 30}))
+//	// -> data-signals: "{user: {name: "Ada", age: 30}}"
+func Struct(name string, v any) Signal {
+	return Signal{key: name, value: reflectToJS(reflect.ValueOf(v), make(map[uintptr]bool))}
+}
+
+// SignalsFrom builds data-signals directly from a Go value via reflection,
+// honoring `datastar:"name,case=kebab|camel|snake|pascal"` struct tags:
+//
+//	type State struct {
+//		Count   int
+//		MsgText string `datastar:"message"`
+//		UserID  int    `datastar:",case=kebab"`
+//	}
+//	ds.SignalsFrom(State{Count: 1, MsgText: "hi", UserID: 7})
+//	// -> data-signals: "{count: 1, message: "hi", "user-id": 7}"
+//
+// Panics if v contains a reference cycle.
+func SignalsFrom(v any, modifiers ...Modifier) templ.Attributes {
+	js := reflectToJS(reflect.ValueOf(v), make(map[uintptr]bool))
+	return templ.Attributes{plugin(attrSignals, modifiers): js}
+}
+
+// reflectToJS renders v as a JS object/array/literal, recursing into
+// structs, maps, slices/arrays, and pointers. visited tracks pointer
+// addresses already on the call stack to detect cycles.
+func reflectToJS(v reflect.Value, visited map[uintptr]bool) string {
+	if !v.IsValid() {
+		return "null"
+	}
+
+	if t, ok := v.Interface().(time.Time); ok {
+		return strconv.Quote(t.Format(time.RFC3339Nano))
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return "null"
+		}
+		if v.Kind() == reflect.Ptr {
+			addr := v.Pointer()
+			if visited[addr] {
+				panic("ds: SignalsFrom/Struct detected a reference cycle")
+			}
+			visited[addr] = true
+			defer delete(visited, addr)
+		}
+		return reflectToJS(v.Elem(), visited)
+
+	case reflect.Struct:
+		return reflectStructToJS(v, visited)
+
+	case reflect.Map:
+		return reflectMapToJS(v, visited)
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return "null"
+		}
+		var b strings.Builder
+		b.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(reflectToJS(v.Index(i), visited))
+		}
+		b.WriteByte(']')
+		return b.String()
+
+	case reflect.String:
+		return strconv.Quote(v.String())
+
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+
+	default:
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			panic(fmt.Sprintf("ds: SignalsFrom/Struct failed to marshal %s: %v", v.Type(), err))
+		}
+		return string(data)
+	}
+}
+
+func reflectStructToJS(v reflect.Value, visited map[uintptr]bool) string {
+	t := v.Type()
+	var b strings.Builder
+	b.WriteByte('{')
+	first := true
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, omit := datastarFieldName(field)
+		if omit {
+			continue
+		}
+		if !first {
+			b.WriteString(", ")
+		}
+		first = false
+		fmt.Fprintf(&b, "%s: %s", jsKey(name), reflectToJS(v.Field(i), visited))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// jsKey renders a signal key as a bare JS identifier when possible, falling
+// back to a quoted string literal for keys (e.g. kebab-case) that aren't
+// valid identifiers.
+func jsKey(k string) string {
+	if isJSIdent(k) {
+		return k
+	}
+	return strconv.Quote(k)
+}
+
+func isJSIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r == '_' || r == '$' || unicode.IsLetter(r):
+		case i > 0 && unicode.IsDigit(r):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func reflectMapToJS(v reflect.Value, visited map[uintptr]bool) string {
+	if v.IsNil() {
+		return "null"
+	}
+	keys := make([]string, 0, v.Len())
+	values := make(map[string]reflect.Value, v.Len())
+	for _, k := range v.MapKeys() {
+		ks := fmt.Sprint(k.Interface())
+		keys = append(keys, ks)
+		values[ks] = v.MapIndex(k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%s: %s", jsKey(k), reflectToJS(values[k], visited))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// datastarFieldName resolves the signal key for a struct field from its
+// `datastar:"name,case=kebab|camel|snake|pascal"` tag, falling back to the
+// field's lower-camel-case name. The second return value is true if the
+// field is tagged `datastar:"-"` and should be skipped entirely.
+func datastarFieldName(field reflect.StructField) (name string, omit bool) {
+	tag, ok := field.Tag.Lookup("datastar")
+	if !ok {
+		return lowerCamelCase(field.Name), false
+	}
+	if tag == "-" {
+		return "", true
+	}
+
+	parts := strings.Split(tag, ",")
+	caseStyle := ""
+	for _, opt := range parts[1:] {
+		if strings.HasPrefix(opt, "case=") {
+			caseStyle = strings.TrimPrefix(opt, "case=")
+		}
+	}
+
+	if parts[0] != "" {
+		return parts[0], false
+	}
+	return applyCase(field.Name, caseStyle), false
+}
+
+func lowerCamelCase(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+// applyCase converts a Go exported field name (PascalCase) into the
+// requested case style. An unrecognized or empty style defaults to camel.
+func applyCase(name, style string) string {
+	words := splitWords(name)
+	switch style {
+	case "kebab":
+		return strings.ToLower(strings.Join(words, "-"))
+	case "snake":
+		return strings.ToLower(strings.Join(words, "_"))
+	case "pascal":
+		for i, w := range words {
+			words[i] = strings.ToUpper(w[:1]) + strings.ToLower(w[1:])
+		}
+		return strings.Join(words, "")
+	default: // camel
+		for i, w := range words {
+			if i == 0 {
+				words[i] = strings.ToLower(w)
+				continue
+			}
+			words[i] = strings.ToUpper(w[:1]) + strings.ToLower(w[1:])
+		}
+		return strings.Join(words, "")
+	}
+}
+
+// splitWords splits a PascalCase/camelCase identifier into its constituent
+// words, e.g. "UserID" -> ["User", "ID"].
+func splitWords(s string) []string {
+	var words []string
+	var cur []rune
+	runes := []rune(s)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			words = append(words, string(cur))
+			cur = nil
+		}
+		cur = append(cur, r)
+	}
+	if len(cur) > 0 {
+		words = append(words, string(cur))
+	}
+	return words
+}
@@ -0,0 +1,113 @@
+package ds_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ds "github.com/Yacobolo/datastar-templ"
+)
+
+func TestSignalsFrom(t *testing.T) {
+	t.Run("flat struct with default field names", func(t *testing.T) {
+		type State struct {
+			Count   int
+			Message string
+		}
+		attrs := ds.SignalsFrom(State{Count: 1, Message: "hi"})
+		require.Len(t, attrs, 1)
+		assert.Equal(t, `{count: 1, message: "hi"}`, attrs["data-signals"])
+	})
+
+	t.Run("tagged field name", func(t *testing.T) {
+		type State struct {
+			MsgText string `datastar:"message"`
+		}
+		attrs := ds.SignalsFrom(State{MsgText: "hi"})
+		assert.Equal(t, `{message: "hi"}`, attrs["data-signals"])
+	})
+
+	t.Run("case=kebab on default name", func(t *testing.T) {
+		type State struct {
+			UserID int `datastar:",case=kebab"`
+		}
+		attrs := ds.SignalsFrom(State{UserID: 7})
+		assert.Equal(t, `{"user-id": 7}`, attrs["data-signals"])
+	})
+
+	t.Run("tagged skip", func(t *testing.T) {
+		type State struct {
+			Count    int
+			Internal string `datastar:"-"`
+		}
+		attrs := ds.SignalsFrom(State{Count: 1, Internal: "secret"})
+		assert.Equal(t, `{count: 1}`, attrs["data-signals"])
+	})
+
+	t.Run("nested struct", func(t *testing.T) {
+		type User struct {
+			Name string
+			Age  int
+		}
+		type State struct {
+			User  User
+			Count int
+		}
+		attrs := ds.SignalsFrom(State{User: User{Name: "a", Age: 1}, Count: 2})
+		assert.Equal(t, `{user: {name: "a", age: 1}, count: 2}`, attrs["data-signals"])
+	})
+
+	t.Run("pointer field", func(t *testing.T) {
+		type State struct {
+			Name *string
+		}
+		attrs := ds.SignalsFrom(State{Name: nil})
+		assert.Equal(t, `{name: null}`, attrs["data-signals"])
+
+		name := "ada"
+		attrs = ds.SignalsFrom(State{Name: &name})
+		assert.Equal(t, `{name: "ada"}`, attrs["data-signals"])
+	})
+
+	t.Run("time.Time field", func(t *testing.T) {
+		type State struct {
+			CreatedAt time.Time
+		}
+		ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		attrs := ds.SignalsFrom(State{CreatedAt: ts})
+		assert.Equal(t, `{createdAt: "2024-01-02T03:04:05Z"}`, attrs["data-signals"])
+	})
+
+	t.Run("map with sorted keys", func(t *testing.T) {
+		attrs := ds.SignalsFrom(map[string]any{"b": 2, "a": 1})
+		assert.Equal(t, `{a: 1, b: 2}`, attrs["data-signals"])
+	})
+
+	t.Run("cycle detection", func(t *testing.T) {
+		type Node struct {
+			Next *Node
+		}
+		n := &Node{}
+		n.Next = n
+		assert.Panics(t, func() {
+			ds.SignalsFrom(n)
+		})
+	})
+
+	t.Run("modifiers apply to the whole attribute", func(t *testing.T) {
+		attrs := ds.SignalsFrom(map[string]any{"count": 1}, ds.ModIfMissing)
+		require.Len(t, attrs, 1)
+		assert.Equal(t, `{count: 1}`, attrs["data-signals__ifmissing"])
+	})
+}
+
+func TestStructSignal(t *testing.T) {
+	type User struct {
+		Name string
+		Age  int
+	}
+	attrs := ds.Signals(ds.Struct("user", User{Name: "Ada", Age: 30}), ds.Int("count", 2))
+	assert.Equal(t, `{user: {name: "Ada", age: 30}, count: 2}`, attrs["data-signals"])
+}
@@ -0,0 +1,240 @@
+package ds
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+)
+
+// ---------------------------------------------------------------------------
+// SignalsFromStruct
+//
+// SignalsFrom/Struct (signals_reflect.go) render a struct as a nested JS
+// object, mirroring the struct's own shape: {user: {name: "Ada"}}.
+// SignalsFromStruct instead flattens it into dotted signal paths — the form
+// Int/String/Bool/Float/JSON already produce by hand ("user.name", not a
+// nested object). It reads the same `datastar:"name,case=..."` tag
+// SignalsFrom/Struct use for naming, extended with two options only
+// flattening needs: `type=` (render hint) and `omitempty` (skip zero
+// values). Use this when a handler wants to stop hand-listing
+// ds.Int("count", s.Count), ds.String("msg", s.Msg), ... for every field.
+// ---------------------------------------------------------------------------
+
+// SignalsFromStruct walks v (a struct, or pointer to one) and emits a single
+// data-signals attribute with one flattened, dotted-path entry per field:
+//
+//	type Profile struct {
+//		Count int    `datastar:"count,type=int"`
+//		Msg   string `datastar:"message,type=string,omitempty"`
+//		User  struct {
+//			Name string `datastar:"name,type=string"`
+//		} `datastar:"user"`
+//	}
+//	ds.SignalsFromStruct(Profile{Count: 1, User: struct{ Name string }{"Ada"}})
+//	// -> data-signals: {"count": 1, "user.name": "Ada"}
+//
+// Field tags share SignalsFrom's `datastar:"name,case=kebab|camel|snake|pascal"`
+// namespace, plus:
+//   - type=int|string|bool|float|json: how to render the value, matching
+//     Int/String/Bool/Float/JSON; omitted, the type is inferred from the
+//     field's Go kind (slices, maps, and other non-struct types fall back to
+//     JSON via DefaultEncoder)
+//   - omitempty: skip the field when it holds its zero value
+//   - `datastar:"-"`: skip the field entirely
+//
+// Nested structs (other than time.Time, which renders as a value like JSON
+// does) recurse, prefixing their own field keys with the parent's, e.g.
+// "user.name". Panics if v is not a struct or struct pointer, or a field's
+// type tag doesn't match its Go kind.
+func SignalsFromStruct(v any, modifiers ...Modifier) templ.Attributes {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("ds: SignalsFromStruct: expected a struct or struct pointer, got %s", rv.Kind()))
+	}
+
+	fields := make(map[string]json.RawMessage)
+	flattenStructSignals("", rv, fields)
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%s: %s", strconv.Quote(k), fields[k])
+	}
+	b.WriteByte('}')
+
+	return templ.Attributes{plugin(attrSignals, modifiers): b.String()}
+}
+
+// structSignalTag is one field's parsed `datastar:"..."` tag.
+type structSignalTag struct {
+	name      string
+	typ       string
+	omitempty bool
+	skip      bool
+}
+
+// parseStructSignalTag reads the same `datastar:"name,case=..."` tag
+// datastarFieldName does, additionally recognizing the `type=` and
+// `omitempty` options SignalsFromStruct needs that plain nested-object
+// rendering (SignalsFrom/Struct) has no use for.
+func parseStructSignalTag(field reflect.StructField) structSignalTag {
+	tag, ok := field.Tag.Lookup("datastar")
+	if !ok {
+		return structSignalTag{name: lowerCamelCase(field.Name)}
+	}
+	if tag == "-" {
+		return structSignalTag{skip: true}
+	}
+
+	parts := strings.Split(tag, ",")
+	caseStyle := ""
+	out := structSignalTag{}
+	for _, opt := range parts[1:] {
+		switch {
+		case opt == "omitempty":
+			out.omitempty = true
+		case strings.HasPrefix(opt, "type="):
+			out.typ = strings.TrimPrefix(opt, "type=")
+		case strings.HasPrefix(opt, "case="):
+			caseStyle = strings.TrimPrefix(opt, "case=")
+		}
+	}
+	if parts[0] != "" {
+		out.name = parts[0]
+	} else {
+		out.name = applyCase(field.Name, caseStyle)
+	}
+	return out
+}
+
+// flattenStructSignals walks v's fields, writing one dotted-path entry into
+// out per leaf field, recursing into nested structs under prefix.
+func flattenStructSignals(prefix string, v reflect.Value, out map[string]json.RawMessage) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := parseStructSignalTag(field)
+		if tag.skip {
+			continue
+		}
+
+		fv := v.Field(i)
+		key := tag.name
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if tag.typ == "" && fv.Kind() == reflect.Struct {
+			if _, isTime := fv.Interface().(time.Time); !isTime {
+				flattenStructSignals(key, fv, out)
+				continue
+			}
+		}
+
+		if tag.omitempty && fv.IsZero() {
+			continue
+		}
+		out[key] = encodeStructSignalField(fv, tag.typ)
+	}
+}
+
+// encodeStructSignalField renders a single field's value as a JSON literal,
+// either under the field's explicit `type=` tag or, with none given,
+// inferred from its Go kind.
+func encodeStructSignalField(fv reflect.Value, typ string) json.RawMessage {
+	switch typ {
+	case "":
+		return inferStructSignalField(fv)
+	case "int":
+		return json.RawMessage(strconv.FormatInt(toSignalInt(fv), 10))
+	case "float":
+		return json.RawMessage(strconv.FormatFloat(toSignalFloat(fv), 'f', -1, 64))
+	case "bool":
+		if fv.Kind() != reflect.Bool {
+			panic(fmt.Sprintf("ds: SignalsFromStruct: type=bool requires a bool field, got %s", fv.Kind()))
+		}
+		return json.RawMessage(strconv.FormatBool(fv.Bool()))
+	case "string":
+		if fv.Kind() != reflect.String {
+			panic(fmt.Sprintf("ds: SignalsFromStruct: type=string requires a string field, got %s", fv.Kind()))
+		}
+		data, _ := json.Marshal(fv.String())
+		return data
+	case "json":
+		data, err := DefaultEncoder.Marshal(fv.Interface())
+		if err != nil {
+			panic(fmt.Sprintf("ds: SignalsFromStruct: failed to marshal %s: %v", fv.Type(), err))
+		}
+		return data
+	default:
+		panic(fmt.Sprintf("ds: SignalsFromStruct: unknown type %q (want int, string, bool, float, or json)", typ))
+	}
+}
+
+// inferStructSignalField renders fv without an explicit type tag, matching
+// Int/String/Bool/Float for the matching Go kinds and falling back to
+// DefaultEncoder for everything else (slices, maps, time.Time, ...).
+func inferStructSignalField(fv reflect.Value) json.RawMessage {
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return json.RawMessage(strconv.FormatInt(fv.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return json.RawMessage(strconv.FormatUint(fv.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		return json.RawMessage(strconv.FormatFloat(fv.Float(), 'f', -1, 64))
+	case reflect.Bool:
+		return json.RawMessage(strconv.FormatBool(fv.Bool()))
+	case reflect.String:
+		data, _ := json.Marshal(fv.String())
+		return data
+	default:
+		data, err := DefaultEncoder.Marshal(fv.Interface())
+		if err != nil {
+			panic(fmt.Sprintf("ds: SignalsFromStruct: failed to marshal %s: %v", fv.Type(), err))
+		}
+		return data
+	}
+}
+
+func toSignalInt(fv reflect.Value) int64 {
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(fv.Uint())
+	default:
+		panic(fmt.Sprintf("ds: SignalsFromStruct: type=int requires an integer field, got %s", fv.Kind()))
+	}
+}
+
+func toSignalFloat(fv reflect.Value) float64 {
+	switch fv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return fv.Float()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(fv.Int())
+	default:
+		panic(fmt.Sprintf("ds: SignalsFromStruct: type=float requires a numeric field, got %s", fv.Kind()))
+	}
+}
@@ -0,0 +1,102 @@
+package ds_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ds "github.com/Yacobolo/datastar-templ"
+)
+
+func TestSignalsFromStruct(t *testing.T) {
+	t.Run("default field names and inferred types", func(t *testing.T) {
+		type State struct {
+			Count   int
+			Message string
+		}
+		attrs := ds.SignalsFromStruct(State{Count: 1, Message: "hi"})
+		require.Len(t, attrs, 1)
+		assert.Equal(t, `{"count": 1, "message": "hi"}`, attrs["data-signals"])
+	})
+
+	t.Run("explicit type tags", func(t *testing.T) {
+		type State struct {
+			Count int     `datastar:"count,type=int"`
+			Ratio float64 `datastar:"ratio,type=float"`
+			Label string  `datastar:"label,type=string"`
+			Done  bool    `datastar:"done,type=bool"`
+		}
+		attrs := ds.SignalsFromStruct(State{Count: 1, Ratio: 0.5, Label: "a", Done: true})
+		assert.Equal(t, `{"count": 1, "done": true, "label": "a", "ratio": 0.5}`, attrs["data-signals"])
+	})
+
+	t.Run("type=json embeds the value's JSON encoding", func(t *testing.T) {
+		type State struct {
+			Tags []string `datastar:"tags,type=json"`
+		}
+		attrs := ds.SignalsFromStruct(State{Tags: []string{"a", "b"}})
+		assert.Equal(t, `{"tags": ["a","b"]}`, attrs["data-signals"])
+	})
+
+	t.Run("omitempty skips zero values", func(t *testing.T) {
+		type State struct {
+			Count int    `datastar:"count"`
+			Msg   string `datastar:"message,omitempty"`
+		}
+		attrs := ds.SignalsFromStruct(State{Count: 1})
+		assert.Equal(t, `{"count": 1}`, attrs["data-signals"])
+
+		attrs = ds.SignalsFromStruct(State{Count: 1, Msg: "hi"})
+		assert.Equal(t, `{"count": 1, "message": "hi"}`, attrs["data-signals"])
+	})
+
+	t.Run("dash tag skips the field entirely", func(t *testing.T) {
+		type State struct {
+			Count    int
+			Internal string `datastar:"-"`
+		}
+		attrs := ds.SignalsFromStruct(State{Count: 1, Internal: "secret"})
+		assert.Equal(t, `{"count": 1}`, attrs["data-signals"])
+	})
+
+	t.Run("nested struct flattens to dotted keys", func(t *testing.T) {
+		type User struct {
+			Name string `datastar:"name"`
+		}
+		type State struct {
+			User  User `datastar:"user"`
+			Count int  `datastar:"count"`
+		}
+		attrs := ds.SignalsFromStruct(State{User: User{Name: "Ada"}, Count: 2})
+		assert.Equal(t, `{"count": 2, "user.name": "Ada"}`, attrs["data-signals"])
+	})
+
+	t.Run("pointer to struct", func(t *testing.T) {
+		type State struct {
+			Count int
+		}
+		attrs := ds.SignalsFromStruct(&State{Count: 1})
+		assert.Equal(t, `{"count": 1}`, attrs["data-signals"])
+	})
+
+	t.Run("panics on type mismatch", func(t *testing.T) {
+		type State struct {
+			Count string `datastar:"count,type=int"`
+		}
+		assert.Panics(t, func() { ds.SignalsFromStruct(State{Count: "nope"}) })
+	})
+
+	t.Run("panics on non-struct input", func(t *testing.T) {
+		assert.Panics(t, func() { ds.SignalsFromStruct(42) })
+	})
+
+	t.Run("modifiers apply to the whole attribute", func(t *testing.T) {
+		type State struct {
+			Count int
+		}
+		attrs := ds.SignalsFromStruct(State{Count: 1}, ds.ModIfMissing)
+		require.Len(t, attrs, 1)
+		assert.Equal(t, `{"count": 1}`, attrs["data-signals__ifmissing"])
+	})
+}
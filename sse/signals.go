@@ -0,0 +1,25 @@
+package sse
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ReadSignals decodes the Datastar signals payload attached to r into dst.
+// GET and DELETE requests carry it JSON-encoded in the "datastar" query
+// parameter; other methods carry it as a JSON request body.
+//
+//	var payload struct{ Count int `json:"count"` }
+//	if err := sse.ReadSignals(r, &payload); err != nil { ... }
+func ReadSignals(r *http.Request, dst any) error {
+	if r.Method == http.MethodGet || r.Method == http.MethodDelete {
+		raw := r.URL.Query().Get("datastar")
+		if raw == "" {
+			return nil
+		}
+		return json.Unmarshal([]byte(raw), dst)
+	}
+
+	defer r.Body.Close()
+	return json.NewDecoder(r.Body).Decode(dst)
+}
@@ -0,0 +1,42 @@
+package sse_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Yacobolo/datastar-templ/sse"
+)
+
+func TestReadSignals_postBody(t *testing.T) {
+	req := httptest.NewRequest("POST", "/api/todos", strings.NewReader(`{"count":3}`))
+
+	var payload struct {
+		Count int `json:"count"`
+	}
+	require.NoError(t, sse.ReadSignals(req, &payload))
+	assert.Equal(t, 3, payload.Count)
+}
+
+func TestReadSignals_getQueryParam(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/todos?datastar="+`{"count":5}`, nil)
+
+	var payload struct {
+		Count int `json:"count"`
+	}
+	require.NoError(t, sse.ReadSignals(req, &payload))
+	assert.Equal(t, 5, payload.Count)
+}
+
+func TestReadSignals_getMissingParam(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/todos", nil)
+
+	var payload struct {
+		Count int `json:"count"`
+	}
+	require.NoError(t, sse.ReadSignals(req, &payload))
+	assert.Equal(t, 0, payload.Count)
+}
@@ -0,0 +1,62 @@
+package sse
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	ds "github.com/Yacobolo/datastar-templ"
+)
+
+// PatchSignalsTyped sends signals built with ds.Int/String/Bool/Float/...
+// as a datastar-patch-signals event, the typed counterpart to PatchSignals
+// that avoids a struct/map round-trip through encoding/json.
+//
+//	w.PatchSignalsTyped(ds.Int("count", 1), ds.String("status", "ok"))
+func (sw *Writer) PatchSignalsTyped(signals ...ds.Signal) error {
+	args := make([]any, len(signals))
+	for i, s := range signals {
+		args[i] = s
+	}
+	body := ds.Signals(args...)["data-signals"].(string)
+	return sw.writeEvent("datastar-patch-signals", patchConfig{}, []string{"signals " + body})
+}
+
+// PatchSignalsIfMatch JSON-marshals v, an object-shaped value, and sends
+// only the top-level keys whose name matches filter's include/exclude
+// regexes as a datastar-patch-signals event. Useful for fanning out one
+// source-of-truth struct to subscribers that only care about part of it.
+func (sw *Writer) PatchSignalsIfMatch(v any, filter ds.Filter) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("sse: marshaling signals: %w", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("sse: PatchSignalsIfMatch requires an object-shaped value: %w", err)
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	matched := make(map[string]json.RawMessage, len(keys))
+	for _, k := range keys {
+		if !filter.Include.MatchesSignal(k) {
+			continue
+		}
+		if filter.Exclude != "" && filter.Exclude.MatchesSignal(k) {
+			continue
+		}
+		matched[k] = fields[k]
+	}
+
+	out, err := json.Marshal(matched)
+	if err != nil {
+		return fmt.Errorf("sse: marshaling filtered signals: %w", err)
+	}
+	return sw.writeEvent("datastar-patch-signals", patchConfig{}, []string{"signals " + string(out)})
+}
@@ -0,0 +1,48 @@
+package sse_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ds "github.com/Yacobolo/datastar-templ"
+	"github.com/Yacobolo/datastar-templ/sse"
+)
+
+func TestPatchSignalsTyped(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/updates", nil)
+	w, err := sse.NewWriter(rec, req)
+	require.NoError(t, err)
+
+	require.NoError(t, w.PatchSignalsTyped(ds.Int("count", 1), ds.String("status", "ok")))
+
+	assert.Equal(t, "event: datastar-patch-signals\ndata: signals {count: 1, status: \"ok\"}\n\n", rec.Body.String())
+}
+
+func TestPatchSignalsIfMatch(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/updates", nil)
+	w, err := sse.NewWriter(rec, req)
+	require.NoError(t, err)
+
+	v := map[string]any{"userName": "ada", "userPassword": "secret", "count": 1}
+	require.NoError(t, w.PatchSignalsIfMatch(v, ds.Filter{Include: "/^user/", Exclude: "/Password/"}))
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `"userName":"ada"`)
+	assert.NotContains(t, body, "userPassword")
+	assert.NotContains(t, body, `"count"`)
+}
+
+func TestPatchSignalsIfMatchNoFilter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/updates", nil)
+	w, err := sse.NewWriter(rec, req)
+	require.NoError(t, err)
+
+	require.NoError(t, w.PatchSignalsIfMatch(map[string]any{"count": 1}, ds.Filter{}))
+	assert.Contains(t, rec.Body.String(), `"count":1`)
+}
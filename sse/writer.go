@@ -0,0 +1,270 @@
+// Package sse provides the server half of the Datastar SSE protocol: a
+// Writer that streams datastar-patch-elements / datastar-patch-signals
+// events to an http.ResponseWriter, pairing with the client-side action
+// builders (ds.Get, ds.Init, ...) in the parent ds package.
+//
+// See https://data-star.dev/reference/sdk
+package sse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/a-h/templ"
+)
+
+// ---------------------------------------------------------------------------
+// Merge modes
+// ---------------------------------------------------------------------------
+
+// MergeMode selects how PatchElements merges new HTML into the DOM.
+type MergeMode string
+
+const (
+	MergeMorph   MergeMode = "morph"
+	MergeInner   MergeMode = "inner"
+	MergeOuter   MergeMode = "outer"
+	MergePrepend MergeMode = "prepend"
+	MergeAppend  MergeMode = "append"
+	MergeBefore  MergeMode = "before"
+	MergeAfter   MergeMode = "after"
+	MergeRemove  MergeMode = "remove"
+)
+
+// ---------------------------------------------------------------------------
+// Patch options
+// ---------------------------------------------------------------------------
+
+type patchConfig struct {
+	selector          string
+	mode              MergeMode
+	settleDuration    time.Duration
+	useViewTransition bool
+	eventID           string
+	retry             time.Duration
+}
+
+// PatchOption configures a PatchElements or RemoveElements call.
+type PatchOption func(*patchConfig)
+
+// WithSelector targets a CSS selector instead of the element's own id.
+func WithSelector(selector string) PatchOption {
+	return func(c *patchConfig) { c.selector = selector }
+}
+
+// WithMode selects the merge mode (default MergeMorph).
+func WithMode(mode MergeMode) PatchOption {
+	return func(c *patchConfig) { c.mode = mode }
+}
+
+// WithSettleDuration sets how long Datastar waits before removing the
+// "starting" CSS class during a view transition.
+func WithSettleDuration(d time.Duration) PatchOption {
+	return func(c *patchConfig) { c.settleDuration = d }
+}
+
+// WithViewTransition enables the View Transition API for this patch.
+func WithViewTransition() PatchOption {
+	return func(c *patchConfig) { c.useViewTransition = true }
+}
+
+// WithEventID sets the SSE event id, used by browsers to resume a dropped
+// connection with Last-Event-ID.
+func WithEventID(id string) PatchOption {
+	return func(c *patchConfig) { c.eventID = id }
+}
+
+// WithRetry sets the client reconnection delay announced on this event.
+func WithRetry(d time.Duration) PatchOption {
+	return func(c *patchConfig) { c.retry = d }
+}
+
+// ---------------------------------------------------------------------------
+// Writer
+// ---------------------------------------------------------------------------
+
+// Writer streams Datastar SSE events to an http.ResponseWriter. Create one
+// with NewWriter per request. Writes are serialized with an internal mutex,
+// so it's safe to call its methods from multiple goroutines at once — most
+// notably the handler goroutine alongside the background goroutine started
+// by StartHeartbeat.
+type Writer struct {
+	w   http.ResponseWriter
+	f   http.Flusher
+	ctx context.Context
+
+	mu sync.Mutex
+}
+
+// NewWriter wraps w, setting the headers required for an SSE stream, and
+// binds the writer's lifetime to r's request context so writes fail once
+// the client disconnects. w must implement http.Flusher.
+func NewWriter(w http.ResponseWriter, r *http.Request) (*Writer, error) {
+	f, ok := w.(http.Flusher)
+	if !ok {
+		return nil, fmt.Errorf("sse: ResponseWriter does not support http.Flusher")
+	}
+
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	f.Flush()
+
+	return &Writer{w: w, f: f, ctx: r.Context()}, nil
+}
+
+// PatchElements renders html and sends it as a datastar-patch-elements
+// event.
+func (sw *Writer) PatchElements(html templ.Component, opts ...PatchOption) error {
+	cfg := patchConfig{mode: MergeMorph}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var buf bytes.Buffer
+	if err := html.Render(sw.ctx, &buf); err != nil {
+		return fmt.Errorf("sse: rendering component: %w", err)
+	}
+
+	var lines []string
+	if cfg.selector != "" {
+		lines = append(lines, "selector "+cfg.selector)
+	}
+	if cfg.mode != "" && cfg.mode != MergeMorph {
+		lines = append(lines, "mode "+string(cfg.mode))
+	}
+	if cfg.settleDuration > 0 {
+		lines = append(lines, fmt.Sprintf("settleDuration %d", cfg.settleDuration.Milliseconds()))
+	}
+	if cfg.useViewTransition {
+		lines = append(lines, "useViewTransition true")
+	}
+	for _, line := range strings.Split(buf.String(), "\n") {
+		lines = append(lines, "elements "+line)
+	}
+
+	return sw.writeEvent("datastar-patch-elements", cfg, lines)
+}
+
+// RemoveElements removes the elements matched by selector.
+func (sw *Writer) RemoveElements(selector string, opts ...PatchOption) error {
+	cfg := patchConfig{mode: MergeRemove, selector: selector}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return sw.writeEvent("datastar-patch-elements", cfg, []string{
+		"selector " + selector,
+		"mode remove",
+	})
+}
+
+// PatchSignals JSON-marshals v and sends it as a datastar-patch-signals
+// event.
+func (sw *Writer) PatchSignals(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("sse: marshaling signals: %w", err)
+	}
+	return sw.writeEvent("datastar-patch-signals", patchConfig{}, []string{"signals " + string(data)})
+}
+
+// ExecuteScript runs js in the browser by patching a <script> tag into the
+// document body.
+func (sw *Writer) ExecuteScript(js string) error {
+	var lines []string
+	lines = append(lines, "selector body", "mode append")
+	script := "<script>" + js + "</script>"
+	for _, line := range strings.Split(script, "\n") {
+		lines = append(lines, "elements "+line)
+	}
+	return sw.writeEvent("datastar-patch-elements", patchConfig{}, lines)
+}
+
+// Redirect navigates the browser to url.
+func (sw *Writer) Redirect(url string) error {
+	return sw.ExecuteScript(fmt.Sprintf("window.location = %q", url))
+}
+
+// StartHeartbeat writes a comment-only keep-alive frame (": heartbeat\n\n")
+// every interval until the request context is done or the returned stop
+// func is called, preventing idle proxies from closing the connection. Its
+// writes are serialized with the rest of Writer's methods, so it's safe to
+// call PatchElements, PatchSignals, etc. from the handler goroutine while a
+// heartbeat is running elsewhere. stop blocks until the heartbeat goroutine
+// has exited:
+//
+//	stop := sw.StartHeartbeat(30 * time.Second)
+//	defer stop()
+func (sw *Writer) StartHeartbeat(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	var once sync.Once
+	stop = func() {
+		once.Do(func() { close(done) })
+		<-stopped
+	}
+
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-sw.ctx.Done():
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				sw.mu.Lock()
+				_, err := io.WriteString(sw.w, ": heartbeat\n\n")
+				if err == nil {
+					sw.f.Flush()
+				}
+				sw.mu.Unlock()
+				if err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	return stop
+}
+
+func (sw *Writer) writeEvent(eventType string, cfg patchConfig, dataLines []string) error {
+	if err := sw.ctx.Err(); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "event: %s\n", eventType)
+	if cfg.eventID != "" {
+		fmt.Fprintf(&b, "id: %s\n", cfg.eventID)
+	}
+	if cfg.retry > 0 {
+		fmt.Fprintf(&b, "retry: %d\n", cfg.retry.Milliseconds())
+	}
+	for _, line := range dataLines {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteByte('\n')
+
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	if _, err := io.WriteString(sw.w, b.String()); err != nil {
+		return err
+	}
+	sw.f.Flush()
+	return nil
+}
@@ -0,0 +1,175 @@
+package sse_test
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Yacobolo/datastar-templ/sse"
+)
+
+func componentHTML(html string) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		_, err := io.WriteString(w, html)
+		return err
+	})
+}
+
+func TestNewWriterSetsHeaders(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/updates", nil)
+
+	_, err := sse.NewWriter(rec, req)
+	require.NoError(t, err)
+
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+	assert.Equal(t, "no", rec.Header().Get("X-Accel-Buffering"))
+}
+
+func TestPatchElements(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/updates", nil)
+	w, err := sse.NewWriter(rec, req)
+	require.NoError(t, err)
+
+	err = w.PatchElements(componentHTML(`<div id="x">hi</div>`), sse.WithSelector("#x"), sse.WithMode(sse.MergeOuter))
+	require.NoError(t, err)
+
+	body := rec.Body.String()
+	assert.Contains(t, body, "event: datastar-patch-elements\n")
+	assert.Contains(t, body, "data: selector #x\n")
+	assert.Contains(t, body, "data: mode outer\n")
+	assert.Contains(t, body, `data: elements <div id="x">hi</div>`)
+}
+
+func TestPatchSignals(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/updates", nil)
+	w, err := sse.NewWriter(rec, req)
+	require.NoError(t, err)
+
+	require.NoError(t, w.PatchSignals(map[string]any{"count": 1}))
+
+	body := rec.Body.String()
+	assert.Contains(t, body, "event: datastar-patch-signals\n")
+	assert.Contains(t, body, `data: signals {"count":1}`)
+}
+
+func TestRemoveElements(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/updates", nil)
+	w, err := sse.NewWriter(rec, req)
+	require.NoError(t, err)
+
+	require.NoError(t, w.RemoveElements("#row-1"))
+
+	body := rec.Body.String()
+	assert.Contains(t, body, "data: selector #row-1\n")
+	assert.Contains(t, body, "data: mode remove\n")
+}
+
+func TestExecuteScriptAndRedirect(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/updates", nil)
+	w, err := sse.NewWriter(rec, req)
+	require.NoError(t, err)
+
+	require.NoError(t, w.ExecuteScript("console.log('hi')"))
+	assert.Contains(t, rec.Body.String(), "console.log")
+
+	rec2 := httptest.NewRecorder()
+	req2 := httptest.NewRequest("GET", "/updates", nil)
+	w2, err := sse.NewWriter(rec2, req2)
+	require.NoError(t, err)
+	require.NoError(t, w2.Redirect("/login"))
+	assert.Contains(t, rec2.Body.String(), `window.location = "/login"`)
+}
+
+func TestPatchSignalsExactFraming(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/updates", nil)
+	w, err := sse.NewWriter(rec, req)
+	require.NoError(t, err)
+
+	require.NoError(t, w.PatchSignals(map[string]any{"count": 1}))
+
+	assert.Equal(t, "event: datastar-patch-signals\ndata: signals {\"count\":1}\n\n", rec.Body.String())
+}
+
+func TestPatchElementsExactFramingWithOptions(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/updates", nil)
+	w, err := sse.NewWriter(rec, req)
+	require.NoError(t, err)
+
+	err = w.PatchElements(
+		componentHTML(`<div id="x">hi</div>`),
+		sse.WithSelector("#x"),
+		sse.WithMode(sse.MergeOuter),
+		sse.WithEventID("42"),
+		sse.WithRetry(2*time.Second),
+	)
+	require.NoError(t, err)
+
+	want := "event: datastar-patch-elements\n" +
+		"id: 42\n" +
+		"retry: 2000\n" +
+		"data: selector #x\n" +
+		"data: mode outer\n" +
+		`data: elements <div id="x">hi</div>` + "\n\n"
+	assert.Equal(t, want, rec.Body.String())
+}
+
+func TestStartHeartbeat(t *testing.T) {
+	rec := httptest.NewRecorder()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req := httptest.NewRequest("GET", "/updates", nil).WithContext(ctx)
+
+	w, err := sse.NewWriter(rec, req)
+	require.NoError(t, err)
+
+	stop := w.StartHeartbeat(5 * time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	stop()
+
+	assert.True(t, strings.Contains(rec.Body.String(), ": heartbeat\n\n"))
+}
+
+func TestHeartbeatConcurrentWithPatchSignals(t *testing.T) {
+	rec := httptest.NewRecorder()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req := httptest.NewRequest("GET", "/updates", nil).WithContext(ctx)
+
+	w, err := sse.NewWriter(rec, req)
+	require.NoError(t, err)
+
+	stop := w.StartHeartbeat(time.Millisecond)
+	defer stop()
+
+	for i := 0; i < 50; i++ {
+		require.NoError(t, w.PatchSignals(map[string]any{"count": i}))
+	}
+}
+
+func TestWriteAfterCancel(t *testing.T) {
+	rec := httptest.NewRecorder()
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest("GET", "/updates", nil).WithContext(ctx)
+
+	w, err := sse.NewWriter(rec, req)
+	require.NoError(t, err)
+
+	cancel()
+	err = w.PatchSignals(map[string]any{"count": 1})
+	assert.Error(t, err)
+}
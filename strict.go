@@ -0,0 +1,129 @@
+package ds
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/a-h/templ"
+)
+
+// ---------------------------------------------------------------------------
+// Strict mode
+//
+// Strict mode trades the package's normal "build a string and move on"
+// behavior for validation at attribute-construction time, catching mistakes
+// that would otherwise silently produce a broken data-* attribute: empty
+// expressions, malformed signal names, and modifier combinations that only
+// make sense on a different attribute. It's a process-wide switch, meant to
+// be enabled in tests/dev builds and left off in production where the extra
+// checks aren't worth paying on every render.
+// ---------------------------------------------------------------------------
+
+var strictMode atomic.Bool
+
+// SetStrict enables or disables strict attribute validation.
+func SetStrict(enabled bool) {
+	strictMode.Store(enabled)
+}
+
+// Strict reports whether strict validation is currently enabled.
+func Strict() bool {
+	return strictMode.Load()
+}
+
+// signalPathRE matches a single valid JS identifier segment.
+var signalPathRE = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// validSignalPath reports whether name is a valid signal reference, allowing
+// dotted nesting (e.g. "table.search").
+func validSignalPath(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, seg := range strings.Split(name, ".") {
+		if !signalPathRE.MatchString(seg) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkExpr panics in strict mode if value is empty.
+func checkExpr(attrName, value string) {
+	if strictMode.Load() && value == "" {
+		panic(fmt.Sprintf("ds: %s: expression must not be empty", attrName))
+	}
+}
+
+// checkModifiers panics in strict mode if modifiers contains a combination
+// that doesn't apply to context (the bare attribute name, e.g. "on-intersect",
+// or "on:click" for DOM event handlers).
+func checkModifiers(context string, modifiers []Modifier) {
+	if !strictMode.Load() || len(modifiers) == 0 {
+		return
+	}
+
+	has := func(m Modifier) bool {
+		for _, x := range modifiers {
+			if x == m {
+				return true
+			}
+		}
+		return false
+	}
+
+	if has(ModThreshold) && context != attrOnIntersect {
+		panic(fmt.Sprintf("ds: %s: __threshold modifier only applies to OnIntersect", context))
+	}
+	if has(ModDuration) && context != attrOnInterval {
+		panic(fmt.Sprintf("ds: %s: __duration modifier only applies to OnInterval", context))
+	}
+	if has(Leading) && has(NoLeading) {
+		panic(fmt.Sprintf("ds: %s: .leading and .noleading are contradictory", context))
+	}
+	if has(Trailing) && has(NoTrailing) {
+		panic(fmt.Sprintf("ds: %s: .trailing and .notrailing are contradictory", context))
+	}
+	if has(ModWindow) && has(ModOutside) && !isClickContext(context) {
+		panic(fmt.Sprintf("ds: %s: __window + __outside only applies to click-family events", context))
+	}
+}
+
+func isClickContext(context string) bool {
+	switch context {
+	case "on:" + eventClick, "on:" + eventDblClick, "on:" + eventMouseDown, "on:" + eventMouseUp:
+		return true
+	default:
+		return false
+	}
+}
+
+// Report collects the unique inline expressions embedded across attrs (the
+// values of data-on:*, data-show, data-effect, and similar expression-valued
+// attributes), sorted, so callers can build a CSP manifest or migrate them
+// to an external, nonce-based expression registry.
+//
+//	ds.Report(ds.OnClick("$open = true"), ds.Show("$open"))
+//	// -> []string{"$open", "$open = true"}
+func Report(attrs ...templ.Attributes) []string {
+	seen := make(map[string]struct{})
+	for _, a := range attrs {
+		for _, v := range a {
+			s, ok := v.(string)
+			if !ok || s == "" {
+				continue
+			}
+			seen[s] = struct{}{}
+		}
+	}
+
+	out := make([]string, 0, len(seen))
+	for s := range seen {
+		out = append(out, s)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,85 @@
+package ds_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ds "github.com/Yacobolo/datastar-templ"
+)
+
+func TestStrictMode(t *testing.T) {
+	require.False(t, ds.Strict(), "strict mode must default to off")
+
+	ds.SetStrict(true)
+	defer ds.SetStrict(false)
+
+	t.Run("empty expression rejected", func(t *testing.T) {
+		assert.Panics(t, func() { ds.Show("") })
+		assert.Panics(t, func() { ds.Text("") })
+		assert.Panics(t, func() { ds.Effect("") })
+		assert.Panics(t, func() { ds.OnClick("") })
+	})
+
+	t.Run("non-empty expressions still work", func(t *testing.T) {
+		assert.NotPanics(t, func() { ds.Show("$visible") })
+	})
+
+	t.Run("invalid signal name in Bind rejected", func(t *testing.T) {
+		assert.Panics(t, func() { ds.Bind("my field") })
+		assert.Panics(t, func() { ds.Bind("") })
+	})
+
+	t.Run("valid signal names in Bind accepted", func(t *testing.T) {
+		assert.NotPanics(t, func() { ds.Bind("name") })
+		assert.NotPanics(t, func() { ds.Bind("table.search") })
+	})
+
+	t.Run("Threshold modifier only valid on OnIntersect", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			ds.OnIntersect("loadMore()", ds.ModThreshold, ds.Threshold(0.5))
+		})
+		assert.Panics(t, func() {
+			ds.OnClick("loadMore()", ds.ModThreshold, ds.Threshold(0.5))
+		})
+	})
+
+	t.Run("Duration modifier only valid on OnInterval", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			ds.OnInterval("poll()", ds.ModDuration, ds.Seconds(5))
+		})
+		assert.Panics(t, func() {
+			ds.OnClick("poll()", ds.ModDuration, ds.Seconds(5))
+		})
+	})
+
+	t.Run("contradictory timing modifiers rejected", func(t *testing.T) {
+		assert.Panics(t, func() {
+			ds.OnClick("handler()", ds.Leading, ds.NoLeading)
+		})
+		assert.Panics(t, func() {
+			ds.OnClick("handler()", ds.Trailing, ds.NoTrailing)
+		})
+	})
+
+	t.Run("window+outside only valid on click-family events", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			ds.OnClick("close()", ds.ModWindow, ds.ModOutside)
+		})
+		assert.Panics(t, func() {
+			ds.OnScroll("close()", ds.ModWindow, ds.ModOutside)
+		})
+	})
+}
+
+func TestStrictModeDisabledByDefault(t *testing.T) {
+	ds.SetStrict(false)
+	assert.NotPanics(t, func() { ds.Show("") })
+	assert.NotPanics(t, func() { ds.Bind("not a valid name") })
+}
+
+func TestReport(t *testing.T) {
+	got := ds.Report(ds.OnClick("$open = true"), ds.Show("$open"), ds.Merge(ds.OnClick("$open = true")))
+	assert.Equal(t, []string{"$open", "$open = true"}, got)
+}
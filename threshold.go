@@ -0,0 +1,273 @@
+package ds
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ---------------------------------------------------------------------------
+// Multi-value thresholds
+//
+// Threshold emits a single ".NN" modifier, but IntersectionObserver (the
+// browser API backing data-on-intersect) accepts a list of thresholds for
+// progressive visibility callbacks, e.g. firing at 25%, 50%, 75% and 100%
+// visible instead of only once. Thresholds covers the literal-list case;
+// ThresholdExpr covers the common "every Nth fraction" / "N equal steps"
+// cases through a tiny pure-Go parser (tokenize, then a one-token-of-lookahead
+// recursive descent) so callers don't hand-compute fractions, and nothing
+// ever goes through a JS evaluator.
+// ---------------------------------------------------------------------------
+
+// thresholdDigits validates t is in (0.0, 1.0] and returns its fraction as
+// the digits Threshold/Thresholds put after the modifier's leading dot
+// (e.g. "50" for 0.5, "100" for 1.0).
+func thresholdDigits(t float64) (string, error) {
+	if t <= 0 || t > 1 {
+		return "", fmt.Errorf("ds: threshold must be between 0.0 (exclusive) and 1.0 (inclusive), got %v", t)
+	}
+	if t == 1 {
+		return "100", nil
+	}
+	formatted := fmt.Sprintf("%.2f", t)
+	if strings.HasPrefix(formatted, "1") {
+		// t rounded up to 1.00 at two decimal places (e.g. 0.999).
+		return "100", nil
+	}
+	return strings.TrimPrefix(formatted, "0."), nil
+}
+
+// Thresholds returns a single modifier covering a sorted, deduplicated list
+// of visibility percentages, for IntersectionObserver's progressive callback
+// support.
+//
+//	ds.OnIntersect("track()", ds.ModThreshold, ds.Thresholds(0.25, 0.5, 0.75, 1))
+//
+// Panics if vals is empty or any value is not in (0.0, 1.0].
+func Thresholds(vals ...float64) Modifier {
+	m, err := ThresholdsSafe(vals...)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// ThresholdsSafe is the error-returning variant of Thresholds, for values
+// that didn't come from a literal call site.
+func ThresholdsSafe(vals ...float64) (Modifier, error) {
+	if len(vals) == 0 {
+		return "", fmt.Errorf("ds: Thresholds requires at least one value")
+	}
+
+	sorted := append([]float64(nil), vals...)
+	sort.Float64s(sorted)
+	deduped := sorted[:0]
+	for i, v := range sorted {
+		if i == 0 || v != sorted[i-1] {
+			deduped = append(deduped, v)
+		}
+	}
+
+	digits := make([]string, len(deduped))
+	for i, v := range deduped {
+		d, err := thresholdDigits(v)
+		if err != nil {
+			return "", err
+		}
+		digits[i] = d
+	}
+	return Modifier("." + strings.Join(digits, ",")), nil
+}
+
+// ThresholdExpr parses a small threshold DSL and returns the same kind of
+// modifier as Thresholds, so callers don't compute fractions by hand:
+//
+//	ds.ThresholdExpr("every(0.25)")     // -> .25,50,75,100
+//	ds.ThresholdExpr("steps(5)")        // -> .20,40,60,80,100
+//	ds.ThresholdExpr("[0.1, 0.5, 0.9]") // -> .10,50,90
+//
+// Panics if expr is malformed or produces an out-of-range value.
+func ThresholdExpr(expr string) Modifier {
+	m, err := ThresholdExprSafe(expr)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// ThresholdExprSafe is the error-returning variant of ThresholdExpr, for
+// expressions coming from user input (e.g. a config file) rather than a
+// literal call site.
+func ThresholdExprSafe(expr string) (Modifier, error) {
+	vals, err := parseThresholdExpr(expr)
+	if err != nil {
+		return "", fmt.Errorf("ds: invalid threshold expression %q: %w", expr, err)
+	}
+	return ThresholdsSafe(vals...)
+}
+
+// thresholdToken is one lexical unit of a threshold DSL expression: an
+// identifier (every, steps), a number, or one of "(", ")", "[", "]", ",".
+type thresholdToken struct {
+	kind string
+	text string
+}
+
+func isThresholdIdentStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+func isThresholdIdentPart(c byte) bool {
+	return isThresholdIdentStart(c) || c >= '0' && c <= '9'
+}
+
+func isThresholdNumberPart(c byte) bool {
+	return c >= '0' && c <= '9' || c == '.'
+}
+
+// tokenizeThresholdExpr lexes expr into idents, numbers, and punctuation.
+func tokenizeThresholdExpr(expr string) ([]thresholdToken, error) {
+	var toks []thresholdToken
+	i := 0
+	for i < len(expr) {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(' || c == ')' || c == '[' || c == ']' || c == ',':
+			toks = append(toks, thresholdToken{kind: string(c), text: string(c)})
+			i++
+		case isThresholdIdentStart(c):
+			j := i + 1
+			for j < len(expr) && isThresholdIdentPart(expr[j]) {
+				j++
+			}
+			toks = append(toks, thresholdToken{kind: "ident", text: expr[i:j]})
+			i = j
+		case c >= '0' && c <= '9':
+			j := i + 1
+			for j < len(expr) && isThresholdNumberPart(expr[j]) {
+				j++
+			}
+			toks = append(toks, thresholdToken{kind: "number", text: expr[i:j]})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+		}
+	}
+	return toks, nil
+}
+
+// parseThresholdExpr dispatches on the first token: "[" starts a literal
+// list, otherwise an identifier names a helper call (every, steps).
+func parseThresholdExpr(expr string) ([]float64, error) {
+	toks, err := tokenizeThresholdExpr(strings.TrimSpace(expr))
+	if err != nil {
+		return nil, err
+	}
+	if len(toks) == 0 {
+		return nil, fmt.Errorf("empty expression")
+	}
+
+	if toks[0].kind == "[" {
+		return parseThresholdList(toks)
+	}
+	if toks[0].kind != "ident" {
+		return nil, fmt.Errorf("expected every(...), steps(...), or [...], got %q", toks[0].text)
+	}
+
+	args, err := parseThresholdCallArgs(toks)
+	if err != nil {
+		return nil, err
+	}
+
+	switch toks[0].text {
+	case "every":
+		return expandEvery(args)
+	case "steps":
+		return expandSteps(args)
+	default:
+		return nil, fmt.Errorf("unknown threshold function %q", toks[0].text)
+	}
+}
+
+// expandEvery expands every(step) into step, 2*step, ... up to and
+// including 1.0.
+func expandEvery(args []float64) ([]float64, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("every() expects exactly one argument, got %d", len(args))
+	}
+	step := args[0]
+	if step <= 0 || step > 1 {
+		return nil, fmt.Errorf("every() step must be in (0.0, 1.0], got %v", step)
+	}
+	var vals []float64
+	for v := step; v < 1-1e-9; v += step {
+		vals = append(vals, v)
+	}
+	return append(vals, 1), nil
+}
+
+// expandSteps expands steps(n) into n equally spaced fractions 1/n, 2/n, ..., 1.
+func expandSteps(args []float64) ([]float64, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("steps() expects exactly one argument, got %d", len(args))
+	}
+	n := args[0]
+	if n != math.Trunc(n) || n < 1 {
+		return nil, fmt.Errorf("steps() count must be a positive integer, got %v", n)
+	}
+	count := int(n)
+	vals := make([]float64, count)
+	for i := 1; i <= count; i++ {
+		vals[i-1] = float64(i) / float64(count)
+	}
+	return vals, nil
+}
+
+// parseThresholdCallArgs parses "name(arg1, arg2, ...)" and returns the
+// argument values.
+func parseThresholdCallArgs(toks []thresholdToken) ([]float64, error) {
+	if len(toks) < 3 || toks[1].kind != "(" {
+		return nil, fmt.Errorf("expected '(' after %q", toks[0].text)
+	}
+	if toks[len(toks)-1].kind != ")" {
+		return nil, fmt.Errorf("expected closing ')'")
+	}
+	return parseThresholdNumberList(toks[2 : len(toks)-1])
+}
+
+// parseThresholdList parses a "[v1, v2, ...]" literal.
+func parseThresholdList(toks []thresholdToken) ([]float64, error) {
+	if toks[len(toks)-1].kind != "]" {
+		return nil, fmt.Errorf("expected closing ']'")
+	}
+	return parseThresholdNumberList(toks[1 : len(toks)-1])
+}
+
+// parseThresholdNumberList parses a comma-separated list of number tokens.
+func parseThresholdNumberList(toks []thresholdToken) ([]float64, error) {
+	var vals []float64
+	expectNumber := true
+	for _, tok := range toks {
+		switch {
+		case expectNumber && tok.kind == "number":
+			v, err := strconv.ParseFloat(tok.text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q: %w", tok.text, err)
+			}
+			vals = append(vals, v)
+			expectNumber = false
+		case !expectNumber && tok.kind == ",":
+			expectNumber = true
+		default:
+			return nil, fmt.Errorf("unexpected token %q", tok.text)
+		}
+	}
+	if expectNumber {
+		return nil, fmt.Errorf("expected a number")
+	}
+	return vals, nil
+}
@@ -0,0 +1,100 @@
+package ds_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ds "github.com/Yacobolo/datastar-templ"
+)
+
+func TestThresholds(t *testing.T) {
+	t.Run("sorts and joins", func(t *testing.T) {
+		assert.Equal(t, ds.Modifier(".25,50,75,100"), ds.Thresholds(0.75, 0.25, 1, 0.5))
+	})
+
+	t.Run("deduplicates", func(t *testing.T) {
+		assert.Equal(t, ds.Modifier(".50,100"), ds.Thresholds(0.5, 0.5, 1, 1))
+	})
+
+	t.Run("single value matches Threshold", func(t *testing.T) {
+		assert.Equal(t, ds.Modifier(".50"), ds.Thresholds(0.5))
+	})
+
+	t.Run("panics on empty", func(t *testing.T) {
+		assert.Panics(t, func() { ds.Thresholds() })
+	})
+
+	t.Run("panics on out-of-range value", func(t *testing.T) {
+		assert.Panics(t, func() { ds.Thresholds(0.5, 1.5) })
+	})
+}
+
+func TestThresholdsSafe(t *testing.T) {
+	t.Run("valid values", func(t *testing.T) {
+		mod, err := ds.ThresholdsSafe(0.25, 0.5, 0.75, 1)
+		require.NoError(t, err)
+		assert.Equal(t, ds.Modifier(".25,50,75,100"), mod)
+	})
+
+	t.Run("empty fails", func(t *testing.T) {
+		_, err := ds.ThresholdsSafe()
+		require.Error(t, err)
+	})
+
+	t.Run("out-of-range value fails", func(t *testing.T) {
+		_, err := ds.ThresholdsSafe(0)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "threshold must be between")
+	})
+}
+
+func TestThresholdExpr(t *testing.T) {
+	t.Run("every", func(t *testing.T) {
+		assert.Equal(t, ds.Modifier(".25,50,75,100"), ds.ThresholdExpr("every(0.25)"))
+	})
+
+	t.Run("steps", func(t *testing.T) {
+		assert.Equal(t, ds.Modifier(".20,40,60,80,100"), ds.ThresholdExpr("steps(5)"))
+	})
+
+	t.Run("literal list", func(t *testing.T) {
+		assert.Equal(t, ds.Modifier(".10,50,90"), ds.ThresholdExpr("[0.1, 0.5, 0.9]"))
+	})
+
+	t.Run("ignores extra whitespace", func(t *testing.T) {
+		assert.Equal(t, ds.Modifier(".10,50,90"), ds.ThresholdExpr("  [ 0.1 , 0.5 , 0.9 ]  "))
+	})
+
+	t.Run("panics on malformed expression", func(t *testing.T) {
+		assert.Panics(t, func() { ds.ThresholdExpr("every(") })
+	})
+
+	t.Run("panics on unknown function", func(t *testing.T) {
+		assert.Panics(t, func() { ds.ThresholdExpr("whatever(1)") })
+	})
+
+	t.Run("panics on non-integer steps count", func(t *testing.T) {
+		assert.Panics(t, func() { ds.ThresholdExpr("steps(2.5)") })
+	})
+}
+
+func TestThresholdExprSafe(t *testing.T) {
+	t.Run("valid expression", func(t *testing.T) {
+		mod, err := ds.ThresholdExprSafe("every(0.5)")
+		require.NoError(t, err)
+		assert.Equal(t, ds.Modifier(".50,100"), mod)
+	})
+
+	t.Run("malformed expression fails", func(t *testing.T) {
+		_, err := ds.ThresholdExprSafe("steps(")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid threshold expression")
+	})
+
+	t.Run("out-of-range every step fails", func(t *testing.T) {
+		_, err := ds.ThresholdExprSafe("every(1.5)")
+		require.Error(t, err)
+	})
+}
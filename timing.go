@@ -0,0 +1,104 @@
+package ds
+
+import (
+	"fmt"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Debounce / Throttle / Delay
+//
+// ModDebounce, ModThrottle, and ModDelay are bare modifier names; the caller
+// still has to append a duration suffix (Duration/Ms) and, for debounce, any
+// .leading/.notrailing timing flags as separate Modifier values, in the
+// right order — easy to typo or concatenate wrong. Debounce and Throttle
+// render the whole thing in one call, and Delay does the same for ModDelay's
+// duration suffix.
+// ---------------------------------------------------------------------------
+
+// TimingOpt configures the .leading/.trailing flags Debounce and Throttle
+// append after their duration suffix.
+type TimingOpt func(*timingConfig)
+
+type timingConfig struct {
+	tags []Modifier
+}
+
+// WithLeading appends ".leading", firing on the leading edge of the timing window.
+func WithLeading() TimingOpt {
+	return func(c *timingConfig) { c.tags = append(c.tags, Leading) }
+}
+
+// WithNoLeading appends ".noleading", suppressing the leading-edge call.
+func WithNoLeading() TimingOpt {
+	return func(c *timingConfig) { c.tags = append(c.tags, NoLeading) }
+}
+
+// WithTrailing appends ".trailing", firing on the trailing edge of the timing window.
+func WithTrailing() TimingOpt {
+	return func(c *timingConfig) { c.tags = append(c.tags, Trailing) }
+}
+
+// WithNoTrailing appends ".notrailing", suppressing the trailing-edge call.
+func WithNoTrailing() TimingOpt {
+	return func(c *timingConfig) { c.tags = append(c.tags, NoTrailing) }
+}
+
+// Debounce returns "__debounce.{d}ms{tags}", combining ModDebounce with its
+// duration suffix and any WithLeading/WithNoLeading/WithTrailing/
+// WithNoTrailing timing flags in one call.
+//
+//	ds.OnInput("@post('/search')", ds.Debounce(300*time.Millisecond, ds.WithLeading()))
+//	// -> "__debounce.300ms.leading"
+//
+// Panics if d is negative, exceeds DefaultMaxDuration, or opts contradict
+// themselves (WithLeading+WithNoLeading, WithTrailing+WithNoTrailing).
+func Debounce(d time.Duration, opts ...TimingOpt) Modifier {
+	return timingModifier(ModDebounce, d, opts)
+}
+
+// Throttle returns "__throttle.{d}ms{tags}", the Throttle counterpart to
+// Debounce.
+//
+// Panics under the same conditions as Debounce.
+func Throttle(d time.Duration, opts ...TimingOpt) Modifier {
+	return timingModifier(ModThrottle, d, opts)
+}
+
+func timingModifier(base Modifier, d time.Duration, opts []TimingOpt) Modifier {
+	var cfg timingConfig
+	for _, o := range opts {
+		o(&cfg)
+	}
+	if hasTag(cfg.tags, Leading) && hasTag(cfg.tags, NoLeading) {
+		panic(fmt.Sprintf("ds: %s: WithLeading and WithNoLeading are contradictory", base))
+	}
+	if hasTag(cfg.tags, Trailing) && hasTag(cfg.tags, NoTrailing) {
+		panic(fmt.Sprintf("ds: %s: WithTrailing and WithNoTrailing are contradictory", base))
+	}
+
+	m := base + Duration(d)
+	for _, tag := range cfg.tags {
+		m += tag
+	}
+	return m
+}
+
+func hasTag(tags []Modifier, m Modifier) bool {
+	for _, t := range tags {
+		if t == m {
+			return true
+		}
+	}
+	return false
+}
+
+// Delay returns "__delay.{d}ms", combining ModDelay with its duration suffix
+// in one call.
+//
+//	ds.Init("@get('/updates')", ds.Delay(500*time.Millisecond))
+//
+// Panics if d is negative or exceeds DefaultMaxDuration.
+func Delay(d time.Duration) Modifier {
+	return ModDelay + Duration(d)
+}
@@ -0,0 +1,67 @@
+package ds_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	ds "github.com/Yacobolo/datastar-templ"
+)
+
+func TestDebounce(t *testing.T) {
+	t.Run("duration only", func(t *testing.T) {
+		assert.Equal(t, ds.Modifier("__debounce.300ms"), ds.Debounce(300*time.Millisecond))
+	})
+
+	t.Run("with leading", func(t *testing.T) {
+		assert.Equal(t, ds.Modifier("__debounce.300ms.leading"), ds.Debounce(300*time.Millisecond, ds.WithLeading()))
+	})
+
+	t.Run("with leading and notrailing", func(t *testing.T) {
+		got := ds.Debounce(300*time.Millisecond, ds.WithLeading(), ds.WithNoTrailing())
+		assert.Equal(t, ds.Modifier("__debounce.300ms.leading.notrailing"), got)
+	})
+
+	t.Run("panics on contradictory leading flags", func(t *testing.T) {
+		assert.Panics(t, func() { ds.Debounce(300*time.Millisecond, ds.WithLeading(), ds.WithNoLeading()) })
+	})
+
+	t.Run("panics on contradictory trailing flags", func(t *testing.T) {
+		assert.Panics(t, func() { ds.Debounce(300*time.Millisecond, ds.WithTrailing(), ds.WithNoTrailing()) })
+	})
+
+	t.Run("panics on negative duration", func(t *testing.T) {
+		assert.Panics(t, func() { ds.Debounce(-time.Millisecond) })
+	})
+
+	t.Run("composes with OnInput", func(t *testing.T) {
+		attrs := ds.OnInput("@post('/search')", ds.Debounce(300*time.Millisecond, ds.WithLeading()))
+		assert.Equal(t, "@post('/search')", attrs["data-on:input__debounce.300ms.leading"])
+	})
+}
+
+func TestThrottle(t *testing.T) {
+	t.Run("duration only", func(t *testing.T) {
+		assert.Equal(t, ds.Modifier("__throttle.500ms"), ds.Throttle(500*time.Millisecond))
+	})
+
+	t.Run("with trailing", func(t *testing.T) {
+		assert.Equal(t, ds.Modifier("__throttle.500ms.trailing"), ds.Throttle(500*time.Millisecond, ds.WithTrailing()))
+	})
+}
+
+func TestDelay(t *testing.T) {
+	t.Run("renders modifier with duration suffix", func(t *testing.T) {
+		assert.Equal(t, ds.Modifier("__delay.500ms"), ds.Delay(500*time.Millisecond))
+	})
+
+	t.Run("composes with Init", func(t *testing.T) {
+		attrs := ds.Init("@get('/updates')", ds.Delay(500*time.Millisecond))
+		assert.Equal(t, "@get('/updates')", attrs["data-init__delay.500ms"])
+	})
+
+	t.Run("panics on negative duration", func(t *testing.T) {
+		assert.Panics(t, func() { ds.Delay(-time.Millisecond) })
+	})
+}